@@ -4,9 +4,12 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"log"
 	"math/big"
+	"net/http"
+	"os"
 	"strings"
 
 	"github.com/Cosmos-Harry/blockchain-qa/cli/internal/bindings"
@@ -23,6 +26,8 @@ var (
 	pollAddress  string
 	choice       uint64
 	merkleProof  string
+	indexerURL   string
+	indexerKey   string
 )
 
 var voteCmd = &cobra.Command{
@@ -38,6 +43,8 @@ func init() {
 	voteCmd.Flags().StringVar(&pollAddress, "poll", "", "Poll contract address (or reads from state)")
 	voteCmd.Flags().Uint64Var(&choice, "choice", 0, "Vote choice (0-indexed)")
 	voteCmd.Flags().StringVar(&merkleProof, "proof", "", "Merkle proof for voter eligibility (comma-separated hashes)")
+	voteCmd.Flags().StringVar(&indexerURL, "indexer-url", os.Getenv("INDEXER_API_URL"), "Indexer API URL to fetch the Merkle proof from when --proof is not given")
+	voteCmd.Flags().StringVar(&indexerKey, "api-key", os.Getenv("INDEXER_API_KEY"), "Indexer API key, needed when the indexer requires one (API_AUTH_REQUIRED)")
 }
 
 func runVote(cmd *cobra.Command, args []string) error {
@@ -109,6 +116,15 @@ func runVote(cmd *cobra.Command, args []string) error {
 			}
 			copy(proofHashes[i][:], hashBytes)
 		}
+	} else if indexerURL != "" {
+		root, err := poll.VoterMerkleRoot(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return fmt.Errorf("failed to read voter merkle root: %w", err)
+		}
+		proofHashes, err = fetchMerkleProof(ctx, indexerURL, indexerKey, common.Hash(root), w.Address())
+		if err != nil {
+			return err
+		}
 	}
 	// If no proof provided, use empty array (works for single-voter merkle trees)
 	log.Printf("Merkle proof hashes count: %d\n", len(proofHashes))
@@ -168,6 +184,51 @@ func computeCommitment(choice uint64, nonce []byte, voter common.Address) common
 	return crypto.Keccak256Hash(data)
 }
 
+// fetchMerkleProof retrieves the voter's eligibility proof from the indexer's
+// Merkle registry, authenticating with apiKey if one is given
+func fetchMerkleProof(ctx context.Context, baseURL, apiKey string, root common.Hash, voter common.Address) ([][32]byte, error) {
+	url := fmt.Sprintf("%s/api/merkle/%s/proof/%s", strings.TrimRight(baseURL, "/"), root.Hex(), voter.Hex())
+	log.Printf("Fetching Merkle proof from %s\n", url)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build proof request: %w", err)
+	}
+	if apiKey != "" {
+		req.Header.Set("X-API-Key", apiKey)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch merkle proof: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Errors come in the API's envelope, e.g. {"error": {"code": "VOTER_NOT_ELIGIBLE", "message": "..."}}
+	var body struct {
+		Proof []common.Hash `json:"proof"`
+		Error struct {
+			Code    string `json:"code"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to fetch merkle proof: HTTP %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to decode merkle proof response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch merkle proof: %s: %s (HTTP %d)", body.Error.Code, body.Error.Message, resp.StatusCode)
+	}
+
+	proof := make([][32]byte, len(body.Proof))
+	for i, h := range body.Proof {
+		proof[i] = h
+	}
+	return proof, nil
+}
+
 // generateDummyZKProof generates a dummy ZK proof for demonstration
 func generateDummyZKProof() []byte {
 	// In production, this would call the Rust ZK prover via FFI or subprocess
@@ -1,16 +1,35 @@
 import { test, expect } from '@playwright/test';
-import { APIHelper } from '../utils/api';
+import { APIHelper, APIErrorResponse } from '../utils/api';
+import { BlockchainHelper } from '../utils/blockchain';
+import { PollContracts } from '../utils/contracts';
+import { SpecValidator } from '../utils/openapi';
 import * as dotenv from 'dotenv';
 
 dotenv.config();
 
 const API_URL = process.env.API_URL || 'http://localhost:3000';
+const RPC_URL = process.env.RPC_URL || 'http://localhost:8545';
+const ADMIN_API_KEY = process.env.ADMIN_API_KEY;
+const POLL_FACTORY_ADDRESS = process.env.POLL_FACTORY_ADDRESS;
+const MOCK_ORACLE_ADDRESS = process.env.MOCK_ORACLE_ADDRESS;
+// Must match the API's own setting
+const VOTE_LINKAGE_AFTER_TALLY = process.env.VOTE_LINKAGE_AFTER_TALLY === 'true';
+const ANVIL_ACCOUNT_0 = '0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266';
+const ANVIL_KEY_0 = '0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80';
+const ANVIL_ACCOUNT_0_ROOT = '0xe9707d0e6171f728f7473c24cc0432a9b07eaaf1efed6a137a4a8c12c79552d9';
 
 test.describe('API Endpoints', () => {
   let api: APIHelper;
+  let blockchain: BlockchainHelper;
+  // Set when the deployed contract addresses are configured
+  let contracts: PollContracts | undefined;
 
   test.beforeAll(() => {
     api = new APIHelper(API_URL);
+    blockchain = new BlockchainHelper(RPC_URL, [ANVIL_KEY_0]);
+    if (POLL_FACTORY_ADDRESS && MOCK_ORACLE_ADDRESS) {
+      contracts = new PollContracts(POLL_FACTORY_ADDRESS, MOCK_ORACLE_ADDRESS, blockchain.accounts[0].signer);
+    }
   });
 
   test('health check endpoint', async () => {
@@ -25,6 +44,17 @@ test.describe('API Endpoints', () => {
     console.log('✓ API health check passed');
   });
 
+  test('readiness reports dependency checks', async ({ request }) => {
+    const response = await request.get(`${API_URL}/health/details`);
+    expect([200, 503]).toContain(response.status());
+
+    const report = await response.json();
+    expect(report.checks.database).toBeDefined();
+    expect(report.checks.indexer).toBeDefined();
+    expect(report.status === 'ok').toBe(response.status() === 200);
+    console.log(`✓ Readiness test: status=${report.status}, indexer=${report.checks.indexer.status}`);
+  });
+
   test('list polls with pagination', async () => {
     // Test with different pagination parameters
     const page1 = await api.listPolls('', 5, 0);
@@ -57,6 +87,182 @@ test.describe('API Endpoints', () => {
     console.log('✓ Get poll test: Non-existent poll returns null');
   });
 
+  test('get poll by address - invalid address rejected', async ({ request }) => {
+    const response = await request.get(`${API_URL}/api/polls/0xnot-an-address`);
+
+    expect(response.status()).toBe(400);
+    const body: APIErrorResponse = await response.json();
+    expect(body.error.code).toBe('INVALID_ADDRESS');
+    expect(body.error.details).toEqual({ parameter: 'address' });
+    expect(body.error.request_id).toBe(response.headers()['x-request-id']);
+    console.log('✓ Get poll test: Invalid address returns 400 INVALID_ADDRESS');
+  });
+
+  test('errors share one envelope with stable codes', async ({ request }) => {
+    const missing = await request.get(`${API_URL}/api/polls/${'0x' + '0'.repeat(40)}`);
+    expect(missing.status()).toBe(404);
+    const body: APIErrorResponse = await missing.json();
+    expect(body.error.code).toBe('POLL_NOT_FOUND');
+    expect(body.error.message).toBeTruthy();
+
+    // A caller-supplied request ID is echoed back
+    const traced = await request.get(`${API_URL}/api/polls/0xnot-an-address`, {
+      headers: { 'X-Request-ID': 'e2e-trace-1' },
+    });
+    expect((await traced.json()).error.request_id).toBe('e2e-trace-1');
+
+    const unknownRoute = await request.get(`${API_URL}/api/no-such-route`);
+    expect(unknownRoute.status()).toBe(404);
+    expect((await unknownRoute.json()).error.code).toBe('NOT_FOUND');
+    console.log('✓ Error model test: Envelope carries code, message and request_id');
+  });
+
+  test('get poll by address - case insensitive lookup', async () => {
+    const polls = await api.listPolls('', 1, 0);
+    if (polls.length === 0) {
+      console.log('⚠ No polls indexed, skipping case-insensitive lookup');
+      return;
+    }
+
+    const address = polls[0].contract_address;
+    const lower = await api.getPoll(address.toLowerCase());
+    const upper = await api.getPoll('0x' + address.slice(2).toUpperCase());
+
+    expect(lower?.contract_address).toBe(address);
+    expect(upper?.contract_address).toBe(address);
+    console.log('✓ Get poll test: Lookup is case-insensitive and returns checksummed address');
+  });
+
+  test('batch poll lookup returns stats and results with per-item errors', async ({ request }) => {
+    const polls = await api.listPolls('', 5, 0);
+    const missing = '0x' + '0'.repeat(40);
+    const addresses = [...polls.map((p: any) => p.contract_address.toLowerCase()), missing, 'not-an-address'];
+
+    const response = await request.post(`${API_URL}/api/polls/batch`, { data: { addresses } });
+    expect(response.ok()).toBeTruthy();
+    const body = await response.json();
+    expect(body.items).toHaveLength(addresses.length);
+    expect(body.found).toBe(polls.length);
+    expect(body.not_found).toBe(2);
+
+    for (const [i, poll] of polls.entries()) {
+      const item = body.items[i];
+      expect(item.address).toBe(addresses[i]);
+      expect(item.poll.contract_address).toBe(poll.contract_address);
+      expect(item.stats.total_votes).toBe(item.stats.revealed_votes + item.stats.pending_reveals);
+      if (item.poll.state === 'tallied') {
+        expect(item.result).toBeDefined();
+      }
+    }
+    expect(body.items[polls.length].error.code).toBe('POLL_NOT_FOUND');
+    expect(body.items[polls.length + 1].error.code).toBe('INVALID_ADDRESS');
+
+    const empty = await request.post(`${API_URL}/api/polls/batch`, { data: { addresses: [] } });
+    expect(empty.status()).toBe(400);
+    const tooMany = await request.post(`${API_URL}/api/polls/batch`, {
+      data: { addresses: Array(101).fill(missing) },
+    });
+    expect(tooMany.status()).toBe(400);
+    console.log(`✓ Batch test: ${body.found} polls fetched in one request`);
+  });
+
+  test('chain fallback only serves factory polls the indexer has not stored', async ({ request }) => {
+    // Not deployed by the factory, so neither the index nor the chain fallback knows it
+    const unknown = await request.get(`${API_URL}/api/polls/0x${'1'.repeat(40)}`);
+    expect(unknown.status()).toBe(404);
+    expect((await unknown.json()).error.code).toBe('POLL_NOT_FOUND');
+
+    const polls = await api.listPolls('', 5, 0);
+    for (const poll of polls) {
+      const indexed = await api.getPoll(poll.contract_address);
+      expect(indexed).not.toHaveProperty('source');
+    }
+    console.log('✓ Chain fallback test: Unknown addresses still 404 and indexed polls are served from the index');
+  });
+
+  test('polls the indexer has not stored are read from the chain and queued for indexing', async ({ request }) => {
+    test.skip(!ADMIN_API_KEY || !contracts, 'ADMIN_API_KEY or deployed contract addresses not configured');
+    const headers = { 'X-API-Key': ADMIN_API_KEY! };
+    const creator = blockchain.accounts[0].signer;
+
+    const paused = await request.post(`${API_URL}/api/admin/indexer/pause`, { headers });
+    expect(paused.ok()).toBeTruthy();
+    let address = '';
+    try {
+      // Once every listener has applied the pause, a new poll stays unindexed
+      await expect.poll(async () => {
+        const status = await (await request.get(`${API_URL}/api/admin/indexer`, { headers })).json();
+        return status.listeners.every((l: { paused: boolean }) => l.paused);
+      }, { timeout: 30000 }).toBe(true);
+
+      address = await contracts!.createPoll(creator, 'Served before it is indexed?', creator.address);
+
+      const response = await request.get(`${API_URL}/api/polls/${address}`);
+      expect(response.ok()).toBeTruthy();
+      const poll = await response.json();
+      expect(poll.source).toBe('chain');
+      expect(poll.contract_address).toBe(address);
+      expect(poll.state).toBe('active');
+      expect(poll.total_committed).toBe(0);
+      // The creator is the account that called the factory, not the factory
+      expect(poll.creator.toLowerCase()).toBe(creator.address.toLowerCase());
+      expect(poll.factory.toLowerCase()).toBe(POLL_FACTORY_ADDRESS!.toLowerCase());
+
+      // Repeated reads reuse the chain read and queue no further reindex
+      const again = await request.get(`${API_URL}/api/polls/${address}`);
+      expect((await again.json()).source).toBe('chain');
+
+      const status = await (await request.get(`${API_URL}/api/admin/indexer`, { headers })).json();
+      const queued = status.recent_commands.filter(
+        (cmd: { kind: string; poll_address?: string }) =>
+          cmd.kind === 'reindex_poll' && cmd.poll_address?.toLowerCase() === address.toLowerCase(),
+      );
+      expect(queued, 'one reindex of the poll should be queued').toHaveLength(1);
+      expect(queued[0].from_block).toBe(poll.block_number);
+    } finally {
+      await request.post(`${API_URL}/api/admin/indexer/resume`, { headers });
+    }
+
+    // Once stored, the poll is served from the index
+    await expect.poll(async () => {
+      const poll = await (await request.get(`${API_URL}/api/polls/${address}`)).json();
+      return poll.source ?? 'index';
+    }, { timeout: 30000 }).toBe('index');
+    console.log(`✓ Chain fallback test: ${address} was served from the chain, then indexed`);
+  });
+
+  test('poll reads revalidate with ETags and 304 Not Modified', async ({ request }) => {
+    const polls = await api.listPolls('', 5, 0);
+    for (const poll of polls) {
+      const base = `${API_URL}/api/polls/${poll.contract_address}`;
+      for (const url of [base, `${base}/votes`, `${base}/results`, `${base}/stats`]) {
+        const first = await request.get(url);
+        expect(first.ok()).toBeTruthy();
+        const etag = first.headers()['etag'];
+        const lastModified = first.headers()['last-modified'];
+        expect(etag).toMatch(/^W\/"\d+-[0-9a-f]+"$/);
+        expect(lastModified).toBeDefined();
+
+        const revalidated = await request.get(url, { headers: { 'If-None-Match': etag } });
+        expect(revalidated.status()).toBe(304);
+        expect(revalidated.headers()['etag']).toBe(etag);
+
+        const stale = await request.get(url, { headers: { 'If-None-Match': 'W/"0-0"' } });
+        expect(stale.status()).toBe(200);
+      }
+
+      const results = await request.get(`${base}/results`);
+      const cacheControl = results.headers()['cache-control'];
+      if (poll.state === 'tallied') {
+        expect(cacheControl).toMatch(/max-age=\d+/);
+        expect(cacheControl).not.toContain('immutable');
+      } else {
+        expect(cacheControl).toBe('no-cache');
+      }
+    }
+    console.log(`✓ Conditional GET test: ${polls.length} polls revalidated with If-None-Match`);
+  });
+
   test('get vote stats - error handling', async () => {
     const nonExistentAddress = '0x' + '0'.repeat(40);
 
@@ -79,6 +285,676 @@ test.describe('API Endpoints', () => {
     console.log('✓ Get results test: Non-existent poll results return null');
   });
 
+  test('get provisional results - not found', async () => {
+    const results = await api.getProvisionalResults('0x' + '0'.repeat(40));
+
+    expect(results).toBeNull();
+    console.log('✓ Provisional results test: Non-existent poll returns null');
+  });
+
+  test('timeseries bucket is validated', async ({ request }) => {
+    const address = '0x' + '0'.repeat(40);
+    const invalid = await request.get(`${API_URL}/api/polls/${address}/timeseries?bucket=1d`);
+    expect(invalid.status()).toBe(400);
+
+    expect((await invalid.json()).error.code).toBe('INVALID_PARAMETER');
+
+    const missing = await request.get(`${API_URL}/api/polls/${address}/timeseries?bucket=block`);
+    expect(missing.status()).toBe(404);
+    expect((await missing.json()).error.code).toBe('POLL_NOT_FOUND');
+    console.log('✓ Timeseries test: Bucket sizes are validated and unknown polls return 404');
+  });
+
+  test('audit export validates format', async ({ request }) => {
+    const address = '0x' + '0'.repeat(40);
+    const invalid = await request.get(`${API_URL}/api/polls/${address}/export?format=xml`);
+    expect(invalid.status()).toBe(400);
+
+    expect((await invalid.json()).error.code).toBe('INVALID_PARAMETER');
+
+    const missing = await request.get(`${API_URL}/api/polls/${address}/export?format=csv`);
+    expect(missing.status()).toBe(404);
+    expect((await missing.json()).error.code).toBe('POLL_NOT_FOUND');
+    console.log('✓ Export test: Formats are validated and unknown polls return 404');
+  });
+
+  test('audit export of a poll with votes streams its rows', async ({ request }) => {
+    test.skip(!contracts, 'deployed contract addresses not configured');
+    const voter = blockchain.accounts[0].signer;
+    const address = await contracts!.runPoll(voter, 'Exported with its votes?', 1);
+    await expect.poll(async () => {
+      const poll = await (await request.get(`${API_URL}/api/polls/${address}`)).json();
+      return !poll.source && poll.state === 'tallied';
+    }, { timeout: 30000 }).toBe(true);
+
+    // The body is streamed after the handler returns, so both routes must
+    // carry the chain into the export's reads
+    const { default_chain: defaultChain } = await (await request.get(`${API_URL}/api/chains`)).json();
+    for (const base of [`${API_URL}/api`, `${API_URL}/api/chains/${defaultChain}`]) {
+      const exported = await request.get(`${base}/polls/${address}/export?format=json`);
+      expect(exported.ok()).toBeTruthy();
+      const bundle = await exported.json();
+      expect(bundle.poll.contract_address).toBe(address);
+      expect(bundle.events.map((e: { event_name: string }) => e.event_name)).toContain('VoteCommitted');
+      expect(bundle.votes).toHaveLength(1);
+      expect(bundle.votes[0].voter.toLowerCase()).toBe(voter.address.toLowerCase());
+      expect(bundle.result).not.toBeNull();
+
+      const csv = await (await request.get(`${base}/polls/${address}/export?format=csv`)).text();
+      expect(csv.split('\n').filter(line => line.startsWith('vote,'))).toHaveLength(1);
+    }
+    console.log(`✓ Export test: ${address} exported its events, votes and result`);
+  });
+
+  test('get analytics - not found', async ({ request }) => {
+    const response = await request.get(`${API_URL}/api/polls/${'0x' + '0'.repeat(40)}/analytics`);
+    expect(response.status()).toBe(404);
+    expect((await response.json()).error.code).toBe('POLL_NOT_FOUND');
+    console.log('✓ Analytics test: Non-existent poll returns 404');
+  });
+
+  test('OpenAPI document is served', async () => {
+    const spec = await SpecValidator.load(API_URL);
+
+    expect(spec.responseSchema('listPolls')).toBeDefined();
+    expect(spec.responseSchema('getPoll')).toBeDefined();
+    console.log('✓ OpenAPI test: /api/openapi.json describes the poll routes');
+  });
+
+  test('list polls response matches spec', async ({ request }) => {
+    const spec = await SpecValidator.load(API_URL);
+    const response = await request.get(`${API_URL}/api/polls?limit=10`);
+    expect(response.ok()).toBeTruthy();
+
+    const errors = spec.validate('listPolls', await response.json());
+    expect(errors, errors.join('\n')).toEqual([]);
+    console.log('✓ OpenAPI test: List polls response conforms to schema');
+  });
+
+  test('invalid query parameters are rejected', async ({ request }) => {
+    const spec = await SpecValidator.load(API_URL);
+
+    for (const query of ['limit=0', 'limit=101', 'limit=abc', 'offset=-1', 'state=pending']) {
+      const response = await request.get(`${API_URL}/api/polls?${query}`);
+      expect(response.status(), query).toBe(400);
+      expect(spec.validate('listPolls', await response.json(), 400)).toEqual([]);
+    }
+
+    const votes = await request.get(`${API_URL}/api/polls/${'0x' + '0'.repeat(40)}/votes?revealed_only=maybe`);
+    expect(votes.status()).toBe(400);
+    console.log('✓ OpenAPI test: Query parameters are validated against the spec');
+  });
+
+  test('admin routes require an admin API key', async ({ request }) => {
+    const anonymous = await request.get(`${API_URL}/api/admin/keys`);
+    expect(anonymous.status()).toBe(401);
+    expect((await anonymous.json()).error.code).toBe('UNAUTHORIZED');
+
+    const invalid = await request.get(`${API_URL}/api/admin/keys`, {
+      headers: { 'X-API-Key': 'bqa_not-a-real-key' },
+    });
+    expect(invalid.status()).toBe(401);
+    console.log('✓ Auth test: Admin routes reject missing and unknown keys');
+  });
+
+  test('read-scoped keys cannot call admin routes', async ({ request }) => {
+    test.skip(!ADMIN_API_KEY, 'ADMIN_API_KEY not configured');
+
+    const created = await request.post(`${API_URL}/api/admin/keys`, {
+      headers: { 'X-API-Key': ADMIN_API_KEY! },
+      data: { name: 'e2e-read-key', scopes: ['read'] },
+    });
+    expect(created.status()).toBe(201);
+    const { id, key } = await created.json();
+
+    const forbidden = await request.get(`${API_URL}/api/admin/keys`, {
+      headers: { 'X-API-Key': key },
+    });
+    expect(forbidden.status()).toBe(403);
+    expect((await forbidden.json()).error.code).toBe('FORBIDDEN');
+
+    const polls = await request.get(`${API_URL}/api/polls`, {
+      headers: { 'X-API-Key': key },
+    });
+    expect(polls.ok()).toBeTruthy();
+    expect(polls.headers()['x-ratelimit-limit']).toBeDefined();
+
+    const revoked = await request.delete(`${API_URL}/api/admin/keys/${id}`, {
+      headers: { 'X-API-Key': ADMIN_API_KEY! },
+    });
+    expect(revoked.status()).toBe(204);
+    console.log('✓ Auth test: Read scope is enforced on admin routes');
+  });
+
+  test('indexer control routes report status and validate reindex requests', async ({ request }) => {
+    const anonymous = await request.get(`${API_URL}/api/admin/indexer`);
+    expect(anonymous.status()).toBe(401);
+
+    test.skip(!ADMIN_API_KEY, 'ADMIN_API_KEY not configured');
+    const headers = { 'X-API-Key': ADMIN_API_KEY! };
+
+    const status = await request.get(`${API_URL}/api/admin/indexer`, { headers });
+    expect(status.ok()).toBeTruthy();
+    const body = await status.json();
+    expect(typeof body.paused).toBe('boolean');
+    expect(Array.isArray(body.listeners)).toBeTruthy();
+    for (const listener of body.listeners) {
+      expect(listener.lag_blocks).toBeGreaterThanOrEqual(0);
+    }
+
+    // Pause and resume round-trip through the control table
+    const paused = await request.post(`${API_URL}/api/admin/indexer/pause`, { headers });
+    expect(paused.ok()).toBeTruthy();
+    expect((await paused.json()).paused).toBe(true);
+    const resumed = await request.post(`${API_URL}/api/admin/indexer/resume`, { headers });
+    expect(resumed.ok()).toBeTruthy();
+    expect((await resumed.json()).paused).toBe(false);
+
+    const empty = await request.post(`${API_URL}/api/admin/indexer/reindex`, { headers, data: {} });
+    expect(empty.status()).toBe(400);
+    expect((await empty.json()).error.code).toBe('INVALID_REQUEST');
+
+    const backwards = await request.post(`${API_URL}/api/admin/indexer/reindex`, {
+      headers,
+      data: { from_block: 10, to_block: 5 },
+    });
+    expect(backwards.status()).toBe(400);
+
+    const unknownPoll = await request.post(`${API_URL}/api/admin/indexer/reindex`, {
+      headers,
+      data: { poll_address: '0x' + '0'.repeat(40) },
+    });
+    expect(unknownPoll.status()).toBe(404);
+    expect((await unknownPoll.json()).error.code).toBe('POLL_NOT_FOUND');
+
+    const queued = await request.post(`${API_URL}/api/admin/indexer/reindex`, {
+      headers,
+      data: { from_block: 0, to_block: 0 },
+    });
+    expect(queued.status()).toBe(202);
+    const command = await queued.json();
+    expect(command.kind).toBe('reindex_range');
+    expect(command.status).toBe('pending');
+
+    const failed = await request.get(`${API_URL}/api/admin/indexer/failed-events`, { headers });
+    expect(failed.ok()).toBeTruthy();
+    expect(Array.isArray((await failed.json()).events)).toBeTruthy();
+
+    const flushed = await request.post(`${API_URL}/api/admin/cache/flush`, { headers });
+    expect(flushed.ok()).toBeTruthy();
+    expect((await flushed.json()).deleted).toBeGreaterThanOrEqual(0);
+    console.log('✓ Admin test: Indexer status, pause/resume, reindex, failed events and cache flush');
+  });
+
+  test('webhook subscriptions can be managed by admins', async ({ request }) => {
+    const anonymous = await request.get(`${API_URL}/api/admin/webhooks`);
+    expect(anonymous.status()).toBe(401);
+
+    test.skip(!ADMIN_API_KEY, 'ADMIN_API_KEY not configured');
+    const headers = { 'X-API-Key': ADMIN_API_KEY! };
+
+    const invalidURL = await request.post(`${API_URL}/api/admin/webhooks`, {
+      headers,
+      data: { url: 'ftp://example.com/hook' },
+    });
+    expect(invalidURL.status()).toBe(400);
+    expect((await invalidURL.json()).error.code).toBe('INVALID_REQUEST');
+
+    const unknownEvent = await request.post(`${API_URL}/api/admin/webhooks`, {
+      headers,
+      data: { url: 'https://example.com/hook', event_types: ['poll.exploded'] },
+    });
+    expect(unknownEvent.status()).toBe(400);
+
+    const created = await request.post(`${API_URL}/api/admin/webhooks`, {
+      headers,
+      data: { url: 'https://example.com/hook', event_types: ['poll.tallied'], creator: ANVIL_ACCOUNT_0 },
+    });
+    expect(created.status()).toBe(201);
+    const hook = await created.json();
+    expect(hook.secret).toMatch(/^whsec_[0-9a-f]{64}$/);
+    expect(hook.creator).toBe(ANVIL_ACCOUNT_0);
+
+    // The secret is only returned on creation
+    const list = await request.get(`${API_URL}/api/admin/webhooks`, { headers });
+    expect(list.ok()).toBeTruthy();
+    const listed = (await list.json()).webhooks.find((w: { id: number }) => w.id === hook.id);
+    expect(listed).toBeDefined();
+    expect(listed.secret).toBeUndefined();
+
+    const deliveries = await request.get(`${API_URL}/api/admin/webhooks/${hook.id}/deliveries`, { headers });
+    expect(deliveries.ok()).toBeTruthy();
+    expect((await deliveries.json()).deliveries).toEqual([]);
+
+    const missingReplay = await request.post(`${API_URL}/api/admin/webhooks/deliveries/999999999/replay`, { headers });
+    expect(missingReplay.status()).toBe(404);
+    expect((await missingReplay.json()).error.code).toBe('DELIVERY_NOT_FOUND');
+
+    const deleted = await request.delete(`${API_URL}/api/admin/webhooks/${hook.id}`, { headers });
+    expect(deleted.status()).toBe(204);
+
+    const gone = await request.delete(`${API_URL}/api/admin/webhooks/${hook.id}`, { headers });
+    expect(gone.status()).toBe(404);
+    expect((await gone.json()).error.code).toBe('WEBHOOK_NOT_FOUND');
+    console.log('✓ Webhook test: Subscriptions are validated, listed without secrets and deleted');
+  });
+
+  test('vote responses never leak nonces or unrevealed choices', async ({ request }) => {
+    const list = await request.get(`${API_URL}/api/polls?limit=10`);
+    expect(list.ok()).toBeTruthy();
+    const { polls } = await list.json();
+
+    for (const poll of polls) {
+      for (const revealedOnly of [false, true]) {
+        const res = await request.get(`${API_URL}/api/polls/${poll.contract_address}/votes?revealed_only=${revealedOnly}`);
+        expect(res.ok()).toBeTruthy();
+        for (const vote of (await res.json()).votes) {
+          expect(vote).not.toHaveProperty('nonce');
+          if (!vote.revealed) {
+            expect(vote).not.toHaveProperty('choice');
+          }
+        }
+      }
+
+      const exported = await request.get(`${API_URL}/api/polls/${poll.contract_address}/export?format=json`);
+      expect(exported.ok()).toBeTruthy();
+      const bundle = await exported.json();
+      for (const vote of bundle.votes) {
+        expect(vote).not.toHaveProperty('nonce');
+        if (!vote.revealed) {
+          expect(vote).not.toHaveProperty('choice');
+        }
+      }
+    }
+
+    const gql = await request.post(`${API_URL}/graphql`, {
+      data: { query: '{ polls(first: 10) { votes(first: 100) { revealed choice } } }' },
+    });
+    expect(gql.ok()).toBeTruthy();
+    const body = await gql.json();
+    expect(body.errors).toBeUndefined();
+    for (const poll of body.data.polls) {
+      for (const vote of poll.votes) {
+        if (!vote.revealed) {
+          expect(vote.choice).toBeNull();
+        }
+      }
+    }
+
+    // The nonce is not part of the GraphQL schema at all
+    const nonce = await request.post(`${API_URL}/graphql`, {
+      data: { query: '{ polls(first: 1) { votes { nonce } } }' },
+    });
+    expect((await nonce.json()).errors).toBeDefined();
+    console.log('✓ Privacy test: REST, export and GraphQL vote responses are redacted');
+  });
+
+  test('exports withhold revealed choices from public callers until the tally', async ({ request }) => {
+    test.skip(!VOTE_LINKAGE_AFTER_TALLY, 'VOTE_LINKAGE_AFTER_TALLY not enabled');
+    test.skip(!ADMIN_API_KEY || !contracts, 'ADMIN_API_KEY or deployed contract addresses not configured');
+    const voter = blockchain.accounts[0].signer;
+    const choice = 1;
+
+    const address = await contracts!.runPoll(voter, 'Linked only after the tally?', choice, false);
+    await expect.poll(async () => {
+      const poll = await (await request.get(`${API_URL}/api/polls/${address}`)).json();
+      const { votes } = await (await request.get(`${API_URL}/api/polls/${address}/votes`)).json();
+      return !poll.source && poll.state === 'closed' && votes.length === 1 && votes[0].revealed;
+    }, { timeout: 30000 }).toBe(true);
+
+    const exportJSON = async (headers: Record<string, string> = {}) => {
+      const exported = await request.get(`${API_URL}/api/polls/${address}/export?format=json`, { headers });
+      expect(exported.ok()).toBeTruthy();
+      return exported.json();
+    };
+    const revealedData = (bundle: { events: { event_name: string; event_data: string }[] }) =>
+      bundle.events.filter(e => e.event_name === 'VoteRevealed').map(e => e.event_data);
+
+    // Revealed but not tallied: public callers get neither the choice nor
+    // the raw reveal log, in any format
+    const closed = await exportJSON();
+    expect(closed.votes).toHaveLength(1);
+    expect(closed.votes[0].revealed).toBe(true);
+    expect(closed.votes[0]).not.toHaveProperty('choice');
+    expect(revealedData(closed)).toEqual(['']);
+
+    const csv = await (await request.get(`${API_URL}/api/polls/${address}/export?format=csv`)).text();
+    const [header, ...rows] = csv.trim().split('\n');
+    const choiceColumn = header.split(',').indexOf('choice');
+    const voteRows = rows.filter(row => row.startsWith('vote,'));
+    expect(voteRows).toHaveLength(1);
+    expect(voteRows[0].split(',')[choiceColumn]).toBe('');
+
+    // Admins always see the choice
+    const operator = await exportJSON({ 'X-API-Key': ADMIN_API_KEY! });
+    expect(operator.votes[0].choice).toBe(choice);
+    expect(revealedData(operator)[0]).not.toBe('');
+
+    await contracts!.tally(voter, address);
+    await expect.poll(async () => {
+      const poll = await (await request.get(`${API_URL}/api/polls/${address}`)).json();
+      return poll.state;
+    }, { timeout: 30000 }).toBe('tallied');
+
+    const tallied = await exportJSON();
+    expect(tallied.votes[0].choice).toBe(choice);
+    expect(revealedData(tallied)[0]).not.toBe('');
+    console.log(`✓ Privacy test: ${address} exported choices only to admins until tallied`);
+  });
+
+  test('platform statistics summarize polls, votes and activity', async ({ request }) => {
+    const response = await request.get(`${API_URL}/api/stats?top=5`);
+    expect(response.ok()).toBeTruthy();
+
+    const stats = await response.json();
+    const byState = Object.values(stats.polls_by_state) as number[];
+    expect(byState.reduce((a, b) => a + b, 0)).toBe(stats.total_polls);
+    expect(stats.total_reveals).toBeLessThanOrEqual(stats.total_commits);
+    if (stats.avg_reveal_rate !== null) {
+      expect(stats.avg_reveal_rate).toBeGreaterThanOrEqual(0);
+      expect(stats.avg_reveal_rate).toBeLessThanOrEqual(1);
+    }
+    expect(stats.top_creators.length).toBeLessThanOrEqual(5);
+    expect(stats.top_voters.length).toBeLessThanOrEqual(5);
+    for (let i = 1; i < stats.top_voters.length; i++) {
+      expect(stats.top_voters[i].commits).toBeLessThanOrEqual(stats.top_voters[i - 1].commits);
+    }
+    expect(Date.parse(stats.refreshed_at)).not.toBeNaN();
+
+    const invalid = await request.get(`${API_URL}/api/stats?top=1000`);
+    expect(invalid.status()).toBe(400);
+    console.log(`✓ Stats test: ${stats.total_polls} polls, ${stats.total_commits} commits platform-wide`);
+  });
+
+  test('transactions and blocks can be looked up', async ({ request }) => {
+    const invalid = await request.get(`${API_URL}/api/tx/0x1234`);
+    expect(invalid.status()).toBe(400);
+    expect((await invalid.json()).error.code).toBe('INVALID_TX_HASH');
+
+    const unknown = await request.get(`${API_URL}/api/tx/0x${'0'.repeat(64)}`);
+    expect(unknown.status()).toBe(404);
+    expect((await unknown.json()).error.code).toBe('TRANSACTION_NOT_FOUND');
+
+    const future = await request.get(`${API_URL}/api/blocks/1000000000`);
+    expect(future.status()).toBe(409);
+
+    const list = await request.get(`${API_URL}/api/polls?limit=1`);
+    const { polls } = await list.json();
+    if (polls.length === 0) {
+      console.log('✓ Lookup test: Validation checked (no polls indexed)');
+      return;
+    }
+    const poll = polls[0];
+
+    // The creation transaction carries the factory's PollCreated event
+    const tx = await request.get(`${API_URL}/api/tx/${poll.transaction_hash.toUpperCase().replace('0X', '0x')}`);
+    expect(tx.ok()).toBeTruthy();
+    const body = await tx.json();
+    expect(body.block_number).toBe(poll.block_number);
+    const created = body.events.find((e: any) => e.event_name === 'PollCreated');
+    expect(created.args.pollAddress.toLowerCase()).toBe(poll.contract_address.toLowerCase());
+    expect(body.polls.map((p: any) => p.contract_address)).toContain(poll.contract_address);
+
+    const block = await request.get(`${API_URL}/api/blocks/${poll.block_number}`);
+    expect(block.ok()).toBeTruthy();
+    const blockBody = await block.json();
+    expect(blockBody.count).toBeGreaterThan(0);
+    expect(blockBody.events.map((e: any) => e.transaction_hash)).toContain(poll.transaction_hash);
+    console.log(`✓ Lookup test: Poll creation tx has ${body.events.length} events`);
+  });
+
+  test('active polls past their closing time are reported as overdue', async ({ request }) => {
+    const overdue = await request.get(`${API_URL}/api/polls/overdue?limit=50`);
+    expect(overdue.ok()).toBeTruthy();
+    const body = await overdue.json();
+    expect(['chain_head', 'wall_clock']).toContain(body.chain_time_source);
+    let previous = Infinity;
+    for (const poll of body.polls) {
+      expect(poll.state).toBe('active');
+      expect(poll.status).toBe('overdue');
+      expect(Date.parse(poll.closes_at)).toBeLessThanOrEqual(Date.parse(body.chain_time));
+      expect(poll.overdue_seconds).toBeLessThanOrEqual(previous);
+      previous = poll.overdue_seconds;
+    }
+
+    const filtered = await request.get(`${API_URL}/api/polls?status=overdue&limit=100`);
+    expect(filtered.ok()).toBeTruthy();
+    for (const poll of (await filtered.json()).polls) {
+      expect(poll.status).toBe('overdue');
+    }
+
+    const healthy = await request.get(`${API_URL}/api/polls?status=active&limit=100`);
+    expect(healthy.ok()).toBeTruthy();
+    for (const poll of (await healthy.json()).polls) {
+      expect(poll.status).toBe('active');
+      expect(poll).not.toHaveProperty('overdue_seconds');
+    }
+
+    const invalid = await request.get(`${API_URL}/api/polls?status=late`);
+    expect(invalid.status()).toBe(400);
+    console.log(`✓ Overdue test: ${body.count} polls left open past closes_at`);
+  });
+
+  test('poll annotations attach tags, notes and scenario IDs', async ({ request }) => {
+    const polls = await api.listPolls('', 1, 0);
+    test.skip(polls.length === 0, 'No polls indexed');
+    test.skip(!ADMIN_API_KEY, 'ADMIN_API_KEY not configured');
+    const address = polls[0].contract_address;
+    const url = `${API_URL}/api/polls/${address}/annotations`;
+    const admin = { 'X-API-Key': ADMIN_API_KEY! };
+
+    const anonymous = await request.put(url, { data: { tags: ['late-oracle'] } });
+    expect(anonymous.status()).toBe(401);
+
+    const readKey = await request.post(`${API_URL}/api/admin/keys`, {
+      headers: admin,
+      data: { name: 'e2e-annotation-read-key', scopes: ['read'] },
+    });
+    const { id, key } = await readKey.json();
+    const forbidden = await request.put(url, { headers: { 'X-API-Key': key }, data: { tags: ['late-oracle'] } });
+    expect(forbidden.status()).toBe(403);
+    await request.delete(`${API_URL}/api/admin/keys/${id}`, { headers: admin });
+
+    const invalid = await request.put(url, { headers: admin, data: { tags: ['not a tag'] } });
+    expect(invalid.status()).toBe(400);
+
+    const set = await request.put(url, {
+      headers: admin,
+      data: { tags: ['Late-Oracle', 'late-oracle'], notes: 'oracle answered 10 minutes late', scenario_id: 'fuzz-run-42' },
+    });
+    expect(set.ok()).toBeTruthy();
+    const annotation = await set.json();
+    expect(annotation.tags).toEqual(['late-oracle']);
+    expect(annotation.scenario_id).toBe('fuzz-run-42');
+
+    const added = await request.post(`${url}/tags`, { headers: admin, data: { tags: ['e2e'] } });
+    expect((await added.json()).tags).toEqual(['late-oracle', 'e2e']);
+
+    const poll = await api.getPoll(address);
+    expect(poll?.tags).toEqual(['late-oracle', 'e2e']);
+
+    const tagged = await request.get(`${API_URL}/api/polls?tag=e2e&limit=100`);
+    const taggedPolls = (await tagged.json()).polls;
+    expect(taggedPolls.map((p: any) => p.contract_address)).toContain(address);
+    for (const p of taggedPolls) {
+      expect(p.tags).toContain('e2e');
+    }
+
+    const exported = await request.get(`${API_URL}/api/polls/${address}/export`);
+    expect((await exported.json()).poll.tags).toEqual(['late-oracle', 'e2e']);
+
+    const removed = await request.delete(`${url}/tags/e2e`, { headers: admin });
+    expect((await removed.json()).tags).toEqual(['late-oracle']);
+
+    const deleted = await request.delete(url, { headers: admin });
+    expect(deleted.status()).toBe(204);
+    const cleared = await request.get(url);
+    expect((await cleared.json()).tags).toEqual([]);
+    console.log('✓ Annotation test: Tags show up in poll responses, the tag filter and exports');
+  });
+
+  test('polls, votes and stats can be read as of a past block', async ({ request }) => {
+    const negative = await request.get(`${API_URL}/api/polls/0x0000000000000000000000000000000000000001?at_block=-1`);
+    expect(negative.status()).toBe(400);
+
+    const list = await request.get(`${API_URL}/api/polls?limit=1`);
+    expect(list.ok()).toBeTruthy();
+    const { polls } = await list.json();
+    if (polls.length === 0) {
+      console.log('✓ History test: at_block validated (no polls indexed)');
+      return;
+    }
+
+    const poll = polls[0];
+    const base = `${API_URL}/api/polls/${poll.contract_address}`;
+
+    const before = await request.get(`${base}?at_block=${poll.block_number - 1}`);
+    expect(before.status()).toBe(404);
+
+    const at = await request.get(`${base}?at_block=${poll.block_number}&verify=true`);
+    expect(at.ok()).toBeTruthy();
+    const snapshot = await at.json();
+    expect(snapshot.at_block.number).toBe(poll.block_number);
+    expect(snapshot.at_block.hash).toMatch(/^0x[0-9a-f]{64}$/);
+    expect(snapshot.at_block.timestamp).toBeGreaterThan(0);
+    expect(snapshot.chain_check.matches).toBe(true);
+
+    const stats = await request.get(`${base}/stats?at_block=${poll.block_number}`);
+    expect(stats.ok()).toBeTruthy();
+    const counts = await stats.json();
+    expect(counts.total_votes).toBe(counts.revealed_votes + counts.pending_reveals);
+
+    const votes = await request.get(`${base}/votes?at_block=${poll.block_number}`);
+    expect(votes.ok()).toBeTruthy();
+    expect((await votes.json()).count).toBe(counts.total_votes);
+
+    const future = await request.get(`${base}?at_block=1000000000`);
+    expect(future.status()).toBe(409);
+    expect((await future.json()).error.code).toBe('BLOCK_NOT_INDEXED');
+    console.log('✓ History test: Poll rebuilt at its creation block matches the chain');
+  });
+
+  test('voter list registry builds trees and serves proofs', async ({ request }) => {
+    const anonymous = await request.post(`${API_URL}/api/merkle`, {
+      data: { addresses: [ANVIL_ACCOUNT_0] },
+    });
+    expect(anonymous.status()).toBe(401);
+
+    const invalidRoot = await request.get(`${API_URL}/api/merkle/0x1234/proof/${ANVIL_ACCOUNT_0}`);
+    expect(invalidRoot.status()).toBe(400);
+
+    test.skip(!ADMIN_API_KEY, 'ADMIN_API_KEY not configured');
+
+    // A single-voter tree must match the CLI's default root and need no proof
+    const single = await request.post(`${API_URL}/api/merkle`, {
+      headers: { 'X-API-Key': ADMIN_API_KEY! },
+      data: { addresses: [ANVIL_ACCOUNT_0] },
+    });
+    expect([200, 201]).toContain(single.status());
+    const tree = await single.json();
+    expect(tree.root).toBe(ANVIL_ACCOUNT_0_ROOT);
+    expect(tree.eligible_count).toBe(1);
+
+    const proof = await request.get(`${API_URL}/api/merkle/${tree.root}/proof/${ANVIL_ACCOUNT_0.toLowerCase()}`);
+    expect(proof.ok()).toBeTruthy();
+    expect((await proof.json()).proof).toEqual([]);
+
+    // Three voters: duplicates are ignored and every voter gets a proof
+    const voters = ['0x' + '1'.repeat(40), '0x' + '2'.repeat(40), '0x' + '3'.repeat(40)];
+    const multi = await request.post(`${API_URL}/api/merkle`, {
+      headers: { 'X-API-Key': ADMIN_API_KEY! },
+      data: { addresses: [...voters, voters[0]] },
+    });
+    expect([200, 201]).toContain(multi.status());
+    const multiTree = await multi.json();
+    expect(multiTree.eligible_count).toBe(3);
+
+    for (const voter of voters) {
+      const res = await request.get(`${API_URL}/api/merkle/${multiTree.root}/proof/${voter}`);
+      expect(res.ok()).toBeTruthy();
+      expect((await res.json()).proof).toHaveLength(2);
+    }
+
+    const ineligible = await request.get(`${API_URL}/api/merkle/${multiTree.root}/proof/${'0x' + '4'.repeat(40)}`);
+    expect(ineligible.status()).toBe(404);
+    expect((await ineligible.json()).error.code).toBe('VOTER_NOT_ELIGIBLE');
+    console.log('✓ Merkle test: Trees match the contract rule and proofs are served per voter');
+  });
+
+  test('GraphQL returns polls with nested stats and votes', async ({ request }) => {
+    const response = await request.post(`${API_URL}/graphql`, {
+      data: {
+        query: `query ($first: Int) {
+          polls(first: $first) {
+            address
+            state
+            stats { totalVotes revealedVotes pendingReveals }
+            votes(first: 5) { voter { address } revealed }
+            result { totalVotes }
+          }
+        }`,
+        variables: { first: 5 },
+      },
+    });
+    expect(response.ok()).toBeTruthy();
+
+    const body = await response.json();
+    expect(body.errors).toBeUndefined();
+    expect(Array.isArray(body.data.polls)).toBeTruthy();
+    for (const poll of body.data.polls) {
+      expect(poll.stats.totalVotes).toBe(poll.stats.revealedVotes + poll.stats.pendingReveals);
+    }
+    console.log(`✓ GraphQL test: Fetched ${body.data.polls.length} polls in one request`);
+  });
+
+  test('GraphQL rejects invalid arguments', async ({ request }) => {
+    const response = await request.post(`${API_URL}/graphql`, {
+      data: { query: '{ poll(address: "not-an-address") { address } polls(first: 1000) { address } }' },
+    });
+    expect(response.ok()).toBeTruthy();
+
+    const body = await response.json();
+    expect(body.errors.length).toBe(2);
+    console.log('✓ GraphQL test: Invalid address and page size are reported as errors');
+  });
+
+  test('routes are scoped by chain with a default chain', async ({ request }) => {
+    const chains = await request.get(`${API_URL}/api/chains`);
+    expect(chains.ok()).toBeTruthy();
+    const { chains: served, default_chain: defaultChain } = await chains.json();
+    expect(served.some((c: { chain_id: number; default: boolean }) => c.chain_id === defaultChain && c.default)).toBeTruthy();
+
+    // Unscoped routes read the default chain
+    const unscoped = await request.get(`${API_URL}/api/polls?limit=10`);
+    const scoped = await request.get(`${API_URL}/api/chains/${defaultChain}/polls?limit=10`);
+    expect(scoped.ok()).toBeTruthy();
+    const { polls } = await scoped.json();
+    expect(polls.map((p: { contract_address: string }) => p.contract_address))
+      .toEqual((await unscoped.json()).polls.map((p: { contract_address: string }) => p.contract_address));
+    for (const poll of polls) {
+      expect(poll.chain_id).toBe(defaultChain);
+    }
+
+    const unknown = await request.get(`${API_URL}/api/chains/999999999/polls`);
+    expect(unknown.status()).toBe(404);
+    expect((await unknown.json()).error.code).toBe('CHAIN_NOT_FOUND');
+
+    const invalid = await request.get(`${API_URL}/api/chains/anvil/stats`);
+    expect(invalid.status()).toBe(404);
+    expect((await invalid.json()).error.code).toBe('CHAIN_NOT_FOUND');
+
+    // API keys and voter trees are shared by all chains, so they are only
+    // served unscoped
+    const { paths } = await (await request.get(`${API_URL}/api/openapi.json`)).json();
+    expect(paths['/api/admin/keys']).toBeDefined();
+    expect(paths['/api/merkle/{root}']).toBeDefined();
+    expect(paths['/api/chains/{chainId}/admin/webhooks']).toBeDefined();
+    for (const path of Object.keys(paths)) {
+      expect(path).not.toMatch(/^\/api\/chains\/\{chainId\}\/(admin\/keys|merkle)/);
+    }
+    console.log(`✓ Chain test: ${served.length} chain(s) served, default ${defaultChain}`);
+  });
+
   test('API response time', async () => {
     const start = Date.now();
     await api.listPolls('', 10, 0);
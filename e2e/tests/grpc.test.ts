@@ -0,0 +1,169 @@
+import { test, expect, APIRequestContext } from '@playwright/test';
+import { status } from '@grpc/grpc-js';
+import { BlockchainHelper } from '../utils/blockchain';
+import { PollContracts } from '../utils/contracts';
+import { Poll, PollServiceClient, PollUpdate, Vote } from '../utils/grpc';
+import * as dotenv from 'dotenv';
+
+dotenv.config();
+
+const API_URL = process.env.API_URL || 'http://localhost:3000';
+const GRPC_URL = process.env.GRPC_URL || 'localhost:50051';
+const RPC_URL = process.env.RPC_URL || 'http://localhost:8545';
+const ADMIN_API_KEY = process.env.ADMIN_API_KEY;
+const POLL_FACTORY_ADDRESS = process.env.POLL_FACTORY_ADDRESS;
+const MOCK_ORACLE_ADDRESS = process.env.MOCK_ORACLE_ADDRESS;
+const ANVIL_KEY_0 = '0xac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80';
+
+test.describe('gRPC PollService', () => {
+  let grpc: PollServiceClient;
+  let blockchain: BlockchainHelper;
+  // Set when the deployed contract addresses are configured
+  let contracts: PollContracts | undefined;
+
+  test.beforeAll(() => {
+    grpc = new PollServiceClient(GRPC_URL);
+    blockchain = new BlockchainHelper(RPC_URL, [ANVIL_KEY_0]);
+    if (POLL_FACTORY_ADDRESS && MOCK_ORACLE_ADDRESS) {
+      contracts = new PollContracts(POLL_FACTORY_ADDRESS, MOCK_ORACLE_ADDRESS, blockchain.accounts[0].signer);
+    }
+  });
+
+  test.afterAll(() => grpc.close());
+
+  // Waits until the indexer has stored the poll in state with its vote in
+  // the given reveal state
+  async function waitForIndexed(request: APIRequestContext, address: string, state: string, revealed: boolean) {
+    await expect.poll(async () => {
+      const poll = await (await request.get(`${API_URL}/api/polls/${address}`)).json();
+      if (poll.source || poll.state !== state) {
+        return false;
+      }
+      const { votes } = await (await request.get(`${API_URL}/api/polls/${address}/votes`)).json();
+      return votes.length === 1 && votes[0].revealed === revealed;
+    }, { timeout: 30000 }).toBe(true);
+  }
+
+  test('GetPoll reports unknown polls as NOT_FOUND and invalid addresses as INVALID_ARGUMENT', async () => {
+    const unknown = await grpc.unary<Poll>('GetPoll', { address: '0x' + '0'.repeat(40) });
+    expect(unknown.status).toBe(status.NOT_FOUND);
+    expect(unknown.response).toBeUndefined();
+
+    const invalid = await grpc.unary<Poll>('GetPoll', { address: 'not-an-address' });
+    expect(invalid.status).toBe(status.INVALID_ARGUMENT);
+
+    const badKey = await grpc.unary<Poll>('GetPoll', { address: '0x' + '0'.repeat(40) }, { 'x-api-key': 'not-a-key' });
+    expect(badKey.status).toBe(status.UNAUTHENTICATED);
+    console.log('✓ gRPC test: Unknown polls, invalid addresses and invalid keys are rejected');
+  });
+
+  test('x-chain-id selects the chain and rejects invalid or unknown chains', async ({ request }) => {
+    const { default_chain: defaultChain } = await (await request.get(`${API_URL}/api/chains`)).json();
+
+    const unscoped = await grpc.unary<{ polls: Poll[] }>('ListPolls', { limit: 10 });
+    expect(unscoped.status).toBe(status.OK);
+    const scoped = await grpc.unary<{ polls: Poll[] }>('ListPolls', { limit: 10 }, { 'x-chain-id': String(defaultChain) });
+    expect(scoped.status).toBe(status.OK);
+
+    const polls = scoped.response!.polls;
+    expect(polls.map(p => p.address)).toEqual(unscoped.response!.polls.map(p => p.address));
+    for (const poll of polls) {
+      expect(poll.chainId).toBe(defaultChain);
+    }
+
+    const invalid = await grpc.unary('ListPolls', {}, { 'x-chain-id': 'anvil' });
+    expect(invalid.status).toBe(status.INVALID_ARGUMENT);
+
+    const unknown = await grpc.unary('ListPolls', {}, { 'x-chain-id': '999999999' });
+    expect(unknown.status).toBe(status.NOT_FOUND);
+    console.log(`✓ gRPC test: x-chain-id scopes calls to chain ${defaultChain}`);
+  });
+
+  test('ListVotes redacts choices for anonymous and read-scoped callers', async ({ request }) => {
+    test.skip(!ADMIN_API_KEY || !contracts, 'ADMIN_API_KEY or deployed contract addresses not configured');
+    const voter = blockchain.accounts[0].signer;
+    const choice = 1;
+
+    const created = await request.post(`${API_URL}/api/admin/keys`, {
+      headers: { 'X-API-Key': ADMIN_API_KEY! },
+      data: { name: 'e2e-grpc-read-key', scopes: ['read'] },
+    });
+    expect(created.status()).toBe(201);
+    const readKey = await created.json();
+
+    const viewers: Record<string, Record<string, string>> = {
+      anonymous: {},
+      read: { 'x-api-key': readKey.key },
+      admin: { 'x-api-key': ADMIN_API_KEY! },
+    };
+    const listVotes = async (address: string) => {
+      const votes: Record<string, Vote[]> = {};
+      for (const [viewer, metadata] of Object.entries(viewers)) {
+        const result = await grpc.unary<{ votes: Vote[] }>('ListVotes', { pollAddress: address }, metadata);
+        expect(result.status, viewer).toBe(status.OK);
+        votes[viewer] = result.response!.votes;
+        expect(votes[viewer], viewer).toHaveLength(1);
+      }
+      return votes;
+    };
+
+    try {
+      const address = await contracts!.createPoll(voter, 'Redacted over gRPC?', voter.address);
+      const nonce = await contracts!.commit(voter, address, choice);
+      await waitForIndexed(request, address, 'active', false);
+
+      // An unrevealed vote carries no choice for anyone
+      for (const [viewer, [vote]] of Object.entries(await listVotes(address))) {
+        expect(vote.voter.toLowerCase(), viewer).toBe(voter.address.toLowerCase());
+        expect(vote.revealed, viewer).toBe(false);
+        expect(vote.choice, viewer).toBeUndefined();
+      }
+
+      await contracts!.close(address);
+      await contracts!.reveal(voter, address, choice, nonce);
+      await waitForIndexed(request, address, 'closed', true);
+
+      // Admins see the revealed choice; a read key sees what anonymous
+      // callers see, which depends on VOTE_LINKAGE_AFTER_TALLY
+      const votes = await listVotes(address);
+      expect(votes.admin[0].choice).toBe(choice);
+      for (const viewer of ['anonymous', 'read']) {
+        const [vote] = votes[viewer];
+        expect(vote.revealed, viewer).toBe(true);
+        if (vote.choice !== undefined) {
+          expect(vote.choice, viewer).toBe(choice);
+        }
+      }
+      expect(votes.read[0].choice).toEqual(votes.anonymous[0].choice);
+    } finally {
+      await request.delete(`${API_URL}/api/admin/keys/${readKey.id}`, { headers: { 'X-API-Key': ADMIN_API_KEY! } });
+    }
+    console.log('✓ gRPC test: Vote choices follow the privacy policy per caller');
+  });
+
+  test('WatchPoll streams events and ends once the poll is tallied', async ({ request }) => {
+    test.skip(!contracts, 'deployed contract addresses not configured');
+    const voter = blockchain.accounts[0].signer;
+
+    const address = await contracts!.runPoll(voter, 'Watched until tallied?', 0, false);
+    await waitForIndexed(request, address, 'closed', true);
+
+    // Replay makes the stream independent of when the server reads the
+    // events; it must end on its own after the tally is indexed
+    const watch = grpc.stream<PollUpdate>('WatchPoll', { pollAddress: address, replay: true }, {}, 60000);
+    await contracts!.tally(voter, address);
+    const stream = await watch;
+
+    expect(stream.status, 'stream should end with OK, not time out').toBe(status.OK);
+    expect(stream.messages.length).toBeGreaterThan(1);
+    expect(stream.messages[0].event).toBeNull();
+
+    const last = stream.messages[stream.messages.length - 1];
+    expect(last.poll.state).toBe('POLL_STATE_TALLIED');
+    expect(last.event!.eventName).toBe('ResultsTallied');
+
+    const names = stream.messages.slice(1).map(update => update.event!.eventName);
+    expect(names).toEqual(['VoteCommitted', 'PollClosed', 'VoteRevealed', 'ResultsTallied']);
+    console.log(`✓ gRPC test: WatchPoll sent ${stream.messages.length} updates and ended at the tally`);
+  });
+});
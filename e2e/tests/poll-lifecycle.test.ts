@@ -24,14 +24,9 @@ test.describe('Poll Lifecycle E2E', () => {
     blockchain = new BlockchainHelper(RPC_URL, TEST_KEYS);
     api = new APIHelper(API_URL);
 
-    // Wait for API to be ready (retry up to 30 seconds)
-    let apiHealthy = false;
-    for (let i = 0; i < 30; i++) {
-      apiHealthy = await api.healthCheck();
-      if (apiHealthy) break;
-      await new Promise(resolve => setTimeout(resolve, 1000));
-    }
-    expect(apiHealthy, 'API should be healthy after waiting').toBeTruthy();
+    // Wait for API and indexer to be ready (retry up to 30 seconds)
+    const apiReady = await api.waitForReady(30000);
+    expect(apiReady, 'API should report ready (database up, indexer caught up)').toBeTruthy();
 
     const blockNumber = await blockchain.getBlockNumber();
     expect(blockNumber, 'Blockchain should be running').toBeGreaterThanOrEqual(0);
@@ -130,6 +125,12 @@ test.describe('Poll Lifecycle E2E', () => {
       console.log(`  Revealing vote from ${commit.voter}: choice ${commit.choice}`);
     }
 
+    // Provisional tally is available before tally() is called
+    // In real test:
+    // const provisional = await api.getProvisionalResults(pollAddress)
+    // expect(provisional?.status).toBe('provisional')
+    // expect((provisional as ProvisionalResults).revealed_votes).toBe(3)
+
     // Step 8: Tally results
     console.log('Step 8: Tallying results...');
     // In real test: await poll.tally()
@@ -143,6 +144,7 @@ test.describe('Poll Lifecycle E2E', () => {
     // expect(results.vote_counts[0]).toBe(1) // Red
     // expect(results.vote_counts[1]).toBe(1) // Blue
     // expect(results.vote_counts[2]).toBe(1) // Green
+    // expect(results.provisional_match).toBe(true)
 
     // For now, just verify the flow completed
     expect(commitments.length).toBe(3);
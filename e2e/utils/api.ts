@@ -22,11 +22,24 @@ export interface Vote {
   revealed_at?: string;
 }
 
+export interface APIError {
+  code: string;
+  message: string;
+  details?: Record<string, unknown>;
+  request_id?: string;
+}
+
+export interface APIErrorResponse {
+  error: APIError;
+}
+
 export interface VoteStats {
   poll_address: string;
   total_votes: number;
   revealed_votes: number;
   pending_reveals: number;
+  eligible_voters?: number;
+  turnout?: number;
 }
 
 export interface Results {
@@ -34,17 +47,37 @@ export interface Results {
   vote_counts: number[];
   total_votes: number;
   tallied_at: string;
+  provisional_match?: boolean;
+}
+
+export interface ProvisionalResults {
+  status: 'provisional';
+  provisional: true;
+  poll_state: string;
+  vote_counts: number[];
+  revealed_votes: number;
+  total_votes: number;
+  last_block: number;
 }
 
 export class APIHelper {
   baseURL: string;
+  apiKey?: string;
 
-  constructor(baseURL: string) {
+  constructor(baseURL: string, apiKey?: string) {
     this.baseURL = baseURL;
+    this.apiKey = apiKey;
+  }
+
+  private newContext() {
+    return request.newContext({
+      baseURL: this.baseURL,
+      extraHTTPHeaders: this.apiKey ? { 'X-API-Key': this.apiKey } : {},
+    });
   }
 
   async getPoll(pollAddress: string): Promise<Poll | null> {
-    const context = await request.newContext({ baseURL: this.baseURL });
+    const context = await this.newContext();
     try {
       const response = await context.get(`/api/polls/${pollAddress}`);
       if (response.status() === 404) {
@@ -60,7 +93,7 @@ export class APIHelper {
   }
 
   async listPolls(state?: string, limit: number = 20, offset: number = 0): Promise<Poll[]> {
-    const context = await request.newContext({ baseURL: this.baseURL });
+    const context = await this.newContext();
     try {
       const params = new URLSearchParams({
         limit: limit.toString(),
@@ -82,7 +115,7 @@ export class APIHelper {
   }
 
   async getVotes(pollAddress: string, revealedOnly: boolean = false): Promise<Vote[]> {
-    const context = await request.newContext({ baseURL: this.baseURL });
+    const context = await this.newContext();
     try {
       const params = new URLSearchParams({
         revealed_only: revealedOnly.toString(),
@@ -100,7 +133,7 @@ export class APIHelper {
   }
 
   async getVoteStats(pollAddress: string): Promise<VoteStats> {
-    const context = await request.newContext({ baseURL: this.baseURL });
+    const context = await this.newContext();
     try {
       const response = await context.get(`/api/polls/${pollAddress}/stats`);
       if (!response.ok()) {
@@ -113,7 +146,7 @@ export class APIHelper {
   }
 
   async getResults(pollAddress: string): Promise<Results | null> {
-    const context = await request.newContext({ baseURL: this.baseURL });
+    const context = await this.newContext();
     try {
       const response = await context.get(`/api/polls/${pollAddress}/results`);
       if (response.status() === 404) {
@@ -128,8 +161,24 @@ export class APIHelper {
     }
   }
 
+  async getProvisionalResults(pollAddress: string): Promise<ProvisionalResults | Results | null> {
+    const context = await this.newContext();
+    try {
+      const response = await context.get(`/api/polls/${pollAddress}/results?provisional=true`);
+      if (response.status() === 404) {
+        return null;
+      }
+      if (!response.ok()) {
+        throw new Error(`API error: ${response.status()} ${await response.text()}`);
+      }
+      return await response.json();
+    } finally {
+      await context.dispose();
+    }
+  }
+
   async healthCheck(): Promise<boolean> {
-    const context = await request.newContext({ baseURL: this.baseURL });
+    const context = await this.newContext();
     try {
       const response = await context.get('/health');
       return response.ok();
@@ -140,6 +189,29 @@ export class APIHelper {
     }
   }
 
+  async isReady(): Promise<boolean> {
+    const context = await this.newContext();
+    try {
+      const response = await context.get('/ready');
+      return response.ok();
+    } catch {
+      return false;
+    } finally {
+      await context.dispose();
+    }
+  }
+
+  async waitForReady(timeoutMs: number = 30000): Promise<boolean> {
+    const startTime = Date.now();
+    while (Date.now() - startTime < timeoutMs) {
+      if (await this.isReady()) {
+        return true;
+      }
+      await new Promise(resolve => setTimeout(resolve, 1000)); // Wait 1 second
+    }
+    return false;
+  }
+
   async waitForIndexer(pollAddress: string, timeoutMs: number = 30000): Promise<Poll> {
     const startTime = Date.now();
     while (Date.now() - startTime < timeoutMs) {
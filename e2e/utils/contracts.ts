@@ -0,0 +1,97 @@
+import { ethers } from 'ethers';
+import { computeCommitment, generateNonce } from './blockchain';
+
+const FACTORY_ABI = [
+  'function createPoll(string question, string[] options, uint256 duration, bytes32 voterMerkleRoot) returns (address)',
+  'event PollCreated(uint256 indexed pollId, address indexed pollAddress, address indexed creator, string question, uint256 duration)',
+];
+
+const POLL_ABI = [
+  'function commitVote(bytes32 commitment, bytes zkProof, bytes32[] merklePath)',
+  'function revealVote(uint256 choice, bytes32 salt)',
+  'function tally() returns (uint256[])',
+];
+
+const ORACLE_ABI = ['function manualFulfill(address poll)'];
+
+// The mock verifier accepts any proof
+const DUMMY_ZK_PROOF = '0x' + '00'.repeat(192);
+
+/**
+ * Merkle root of a tree holding only voter: the leaf itself, so the voter's
+ * proof is empty
+ */
+export function singleVoterRoot(voter: string): string {
+  return ethers.keccak256(ethers.solidityPacked(['address'], [voter]));
+}
+
+/**
+ * Drives polls on the contracts deployed by contracts/script/Deploy.s.sol.
+ * The oracle owner (the deployer) closes polls without moving chain time.
+ */
+export class PollContracts {
+  factory: ethers.Contract;
+  oracle: ethers.Contract;
+
+  constructor(factoryAddress: string, oracleAddress: string, oracleOwner: ethers.Wallet) {
+    this.factory = new ethers.Contract(factoryAddress, FACTORY_ABI, oracleOwner);
+    this.oracle = new ethers.Contract(oracleAddress, ORACLE_ABI, oracleOwner);
+  }
+
+  /** Creates a poll the given voter is eligible for and returns its address */
+  async createPoll(creator: ethers.Wallet, question: string, voter: string, duration: number = 3600): Promise<string> {
+    const factory = this.factory.connect(creator) as ethers.Contract;
+    const tx = await factory.createPoll(question, ['Yes', 'No'], duration, singleVoterRoot(voter));
+    const receipt: ethers.TransactionReceipt = await tx.wait();
+
+    for (const log of receipt.logs) {
+      const parsed = this.factory.interface.parseLog(log);
+      if (parsed?.name === 'PollCreated') {
+        return ethers.getAddress(parsed.args.pollAddress);
+      }
+    }
+    throw new Error(`PollCreated not emitted by ${tx.hash}`);
+  }
+
+  /** Commits a vote from a single-voter poll's voter and returns the nonce */
+  async commit(voter: ethers.Wallet, poll: string, choice: number): Promise<string> {
+    const nonce = generateNonce();
+    const contract = new ethers.Contract(poll, POLL_ABI, voter);
+    const tx = await contract.commitVote(computeCommitment(choice, nonce, voter.address), DUMMY_ZK_PROOF, []);
+    await tx.wait();
+    return nonce;
+  }
+
+  /** Closes a poll through the oracle */
+  async close(poll: string): Promise<void> {
+    const tx = await this.oracle.manualFulfill(poll);
+    await tx.wait();
+  }
+
+  async reveal(voter: ethers.Wallet, poll: string, choice: number, nonce: string): Promise<void> {
+    const contract = new ethers.Contract(poll, POLL_ABI, voter);
+    const tx = await contract.revealVote(choice, nonce);
+    await tx.wait();
+  }
+
+  async tally(caller: ethers.Wallet, poll: string): Promise<void> {
+    const contract = new ethers.Contract(poll, POLL_ABI, caller);
+    const tx = await contract.tally();
+    await tx.wait();
+  }
+
+  /**
+   * Runs a single-voter poll through commit, close and reveal, and tallies
+   * it unless told not to. Returns the poll address.
+   */
+  async runPoll(voter: ethers.Wallet, question: string, choice: number, tally: boolean = true): Promise<string> {
+    const poll = await this.createPoll(voter, question, voter.address);
+    const nonce = await this.commit(voter, poll, choice);
+    await this.close(poll);
+    await this.reveal(voter, poll, choice, nonce);
+    if (tally) {
+      await this.tally(voter, poll);
+    }
+    return poll;
+  }
+}
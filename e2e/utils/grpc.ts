@@ -0,0 +1,104 @@
+import * as path from 'path';
+import * as grpc from '@grpc/grpc-js';
+import * as protoLoader from '@grpc/proto-loader';
+
+/** The PollService definition the API serves */
+export const POLL_PROTO = path.resolve(__dirname, '../../indexer/api/poll/v1/poll.proto');
+
+// Field names are camelCased, 64-bit integers read as numbers and enums as
+// their names. Scalars read as their default when unset; proto3 optional
+// fields and unset messages do not, so they can be told apart.
+const packageDefinition = protoLoader.loadSync(POLL_PROTO, {
+  longs: Number,
+  enums: String,
+  defaults: true,
+});
+const pollPackage = (grpc.loadPackageDefinition(packageDefinition) as any).blockchainqa.poll.v1;
+
+export interface Poll {
+  address: string;
+  state: string; // PollState name, e.g. POLL_STATE_TALLIED
+  creator: string;
+  chainId: number;
+}
+
+export interface Vote {
+  voter: string;
+  choice?: number;
+  revealed: boolean;
+}
+
+export interface Event {
+  eventName: string;
+  eventData: string;
+}
+
+export interface PollUpdate {
+  poll: Poll;
+  event: Event | null; // Unset on the first update of a stream
+}
+
+export interface UnaryResult<T> {
+  status: grpc.status;
+  details: string;
+  response?: T;
+}
+
+export interface StreamResult<T> {
+  status: grpc.status; // DEADLINE_EXCEEDED when the stream outlived its deadline
+  details: string;
+  messages: T[];
+}
+
+/**
+ * Client for the API's PollService, generated at load time from the repo's
+ * .proto file. Calls resolve with their status instead of rejecting, so
+ * tests can assert on error codes.
+ */
+export class PollServiceClient {
+  private client: grpc.Client & Record<string, Function>;
+
+  /** target is host:port; an http:// prefix is ignored */
+  constructor(target: string) {
+    this.client = new pollPackage.PollService(target.replace(/^https?:\/\//, ''), grpc.credentials.createInsecure());
+  }
+
+  /** Makes a unary call with metadata such as x-api-key or x-chain-id */
+  unary<T>(method: string, request: object, metadata: Record<string, string> = {}): Promise<UnaryResult<T>> {
+    return new Promise(resolve => {
+      this.client[method](request, toMetadata(metadata), (err: grpc.ServiceError | null, response: T) => {
+        if (err) {
+          resolve({ status: err.code, details: err.details });
+        } else {
+          resolve({ status: grpc.status.OK, details: '', response });
+        }
+      });
+    });
+  }
+
+  /** Reads a server stream until the server ends it or timeoutMs passes */
+  stream<T>(method: string, request: object, metadata: Record<string, string> = {}, timeoutMs: number = 30000): Promise<StreamResult<T>> {
+    return new Promise(resolve => {
+      const call: grpc.ClientReadableStream<T> = this.client[method](request, toMetadata(metadata), {
+        deadline: Date.now() + timeoutMs,
+      });
+      const messages: T[] = [];
+      call.on('data', (message: T) => messages.push(message));
+      // Failures are reported again through 'status'
+      call.on('error', () => {});
+      call.on('status', (status: grpc.StatusObject) => resolve({ status: status.code, details: status.details, messages }));
+    });
+  }
+
+  close() {
+    this.client.close();
+  }
+}
+
+function toMetadata(entries: Record<string, string>): grpc.Metadata {
+  const metadata = new grpc.Metadata();
+  for (const [key, value] of Object.entries(entries)) {
+    metadata.set(key, value);
+  }
+  return metadata;
+}
@@ -0,0 +1,137 @@
+import { request } from '@playwright/test';
+
+export interface Schema {
+  $ref?: string;
+  type?: string;
+  format?: string;
+  pattern?: string;
+  enum?: string[];
+  nullable?: boolean;
+  items?: Schema;
+  properties?: Record<string, Schema>;
+  additionalProperties?: Schema;
+  required?: string[];
+  oneOf?: Schema[];
+}
+
+export interface OpenAPIDocument {
+  openapi: string;
+  paths: Record<string, Record<string, { operationId: string; responses: Record<string, { content?: Record<string, { schema: Schema }> }> }>>;
+  components: { schemas: Record<string, Schema> };
+}
+
+/**
+ * Fetches the OpenAPI document served by the API and validates response
+ * bodies against it, so tests don't need to duplicate response shapes.
+ */
+export class SpecValidator {
+  constructor(private doc: OpenAPIDocument) {}
+
+  static async load(baseURL: string): Promise<SpecValidator> {
+    const context = await request.newContext({ baseURL });
+    try {
+      const response = await context.get('/api/openapi.json');
+      if (!response.ok()) {
+        throw new Error(`API error: ${response.status()} ${await response.text()}`);
+      }
+      return new SpecValidator(await response.json());
+    } finally {
+      await context.dispose();
+    }
+  }
+
+  /** Returns the response schema for an operation and status code */
+  responseSchema(operationId: string, status: number = 200): Schema {
+    for (const methods of Object.values(this.doc.paths)) {
+      for (const op of Object.values(methods)) {
+        if (op.operationId !== operationId) continue;
+        const schema = op.responses[String(status)]?.content?.['application/json']?.schema;
+        if (!schema) {
+          throw new Error(`No ${status} response schema for ${operationId}`);
+        }
+        return schema;
+      }
+    }
+    throw new Error(`Unknown operation: ${operationId}`);
+  }
+
+  /** Validates a body against an operation's response schema, returning a list of errors */
+  validate(operationId: string, body: unknown, status: number = 200): string[] {
+    const errors: string[] = [];
+    this.check(this.responseSchema(operationId, status), body, '$', errors);
+    return errors;
+  }
+
+  private resolve(schema: Schema): Schema {
+    if (!schema.$ref) return schema;
+    const name = schema.$ref.replace('#/components/schemas/', '');
+    const resolved = this.doc.components.schemas[name];
+    if (!resolved) throw new Error(`Unresolved schema reference ${schema.$ref}`);
+    return resolved;
+  }
+
+  private check(input: Schema, value: unknown, path: string, errors: string[]): void {
+    const schema = this.resolve(input);
+
+    if (value === null) {
+      if (!schema.nullable) errors.push(`${path}: unexpected null`);
+      return;
+    }
+
+    if (schema.oneOf) {
+      const matches = schema.oneOf.filter(alt => {
+        const altErrors: string[] = [];
+        this.check(alt, value, path, altErrors);
+        return altErrors.length === 0;
+      });
+      if (matches.length !== 1) errors.push(`${path}: matched ${matches.length} oneOf alternatives`);
+      return;
+    }
+
+    switch (schema.type) {
+      case 'object': {
+        if (typeof value !== 'object' || Array.isArray(value)) {
+          errors.push(`${path}: expected object`);
+          return;
+        }
+        const obj = value as Record<string, unknown>;
+        for (const key of schema.required || []) {
+          if (!(key in obj)) errors.push(`${path}.${key}: missing required property`);
+        }
+        for (const [key, v] of Object.entries(obj)) {
+          const prop = schema.properties?.[key] || schema.additionalProperties;
+          if (!prop) {
+            errors.push(`${path}.${key}: property not in schema`);
+            continue;
+          }
+          this.check(prop, v, `${path}.${key}`, errors);
+        }
+        return;
+      }
+      case 'array':
+        if (!Array.isArray(value)) {
+          errors.push(`${path}: expected array`);
+          return;
+        }
+        value.forEach((item, i) => this.check(schema.items || {}, item, `${path}[${i}]`, errors));
+        return;
+      case 'integer':
+        if (!Number.isInteger(value)) errors.push(`${path}: expected integer`);
+        return;
+      case 'number':
+        if (typeof value !== 'number') errors.push(`${path}: expected number`);
+        return;
+      case 'boolean':
+        if (typeof value !== 'boolean') errors.push(`${path}: expected boolean`);
+        return;
+      case 'string':
+        if (typeof value !== 'string') {
+          errors.push(`${path}: expected string`);
+          return;
+        }
+        if (schema.enum && !schema.enum.includes(value)) errors.push(`${path}: ${value} not in enum`);
+        if (schema.pattern && !new RegExp(schema.pattern).test(value)) errors.push(`${path}: ${value} does not match ${schema.pattern}`);
+        return;
+    }
+  }
+}
@@ -0,0 +1,303 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.3.0
+// - protoc             (unknown)
+// source: api/poll/v1/poll.proto
+
+// Package blockchainqa.poll.v1 serves indexed poll data over gRPC. It reads
+// the same database as the REST and GraphQL APIs and applies the same vote
+// redaction rules.
+
+package pollv1
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+const (
+	PollService_GetPoll_FullMethodName    = "/blockchainqa.poll.v1.PollService/GetPoll"
+	PollService_ListPolls_FullMethodName  = "/blockchainqa.poll.v1.PollService/ListPolls"
+	PollService_ListVotes_FullMethodName  = "/blockchainqa.poll.v1.PollService/ListVotes"
+	PollService_GetResults_FullMethodName = "/blockchainqa.poll.v1.PollService/GetResults"
+	PollService_WatchPoll_FullMethodName  = "/blockchainqa.poll.v1.PollService/WatchPoll"
+)
+
+// PollServiceClient is the client API for PollService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PollServiceClient interface {
+	// GetPoll returns a poll by contract address
+	GetPoll(ctx context.Context, in *GetPollRequest, opts ...grpc.CallOption) (*Poll, error)
+	// ListPolls lists indexed polls, newest first
+	ListPolls(ctx context.Context, in *ListPollsRequest, opts ...grpc.CallOption) (*ListPollsResponse, error)
+	// ListVotes lists a poll's vote commitments in commit order
+	ListVotes(ctx context.Context, in *ListVotesRequest, opts ...grpc.CallOption) (*ListVotesResponse, error)
+	// GetResults returns the tallied results of a poll. It fails with
+	// FAILED_PRECONDITION until the poll is tallied.
+	GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*Result, error)
+	// WatchPoll sends the poll's current state, then one update per event the
+	// indexer stores for it. The stream ends once the poll is tallied.
+	WatchPoll(ctx context.Context, in *WatchPollRequest, opts ...grpc.CallOption) (PollService_WatchPollClient, error)
+}
+
+type pollServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPollServiceClient(cc grpc.ClientConnInterface) PollServiceClient {
+	return &pollServiceClient{cc}
+}
+
+func (c *pollServiceClient) GetPoll(ctx context.Context, in *GetPollRequest, opts ...grpc.CallOption) (*Poll, error) {
+	out := new(Poll)
+	err := c.cc.Invoke(ctx, PollService_GetPoll_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) ListPolls(ctx context.Context, in *ListPollsRequest, opts ...grpc.CallOption) (*ListPollsResponse, error) {
+	out := new(ListPollsResponse)
+	err := c.cc.Invoke(ctx, PollService_ListPolls_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) ListVotes(ctx context.Context, in *ListVotesRequest, opts ...grpc.CallOption) (*ListVotesResponse, error) {
+	out := new(ListVotesResponse)
+	err := c.cc.Invoke(ctx, PollService_ListVotes_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) GetResults(ctx context.Context, in *GetResultsRequest, opts ...grpc.CallOption) (*Result, error) {
+	out := new(Result)
+	err := c.cc.Invoke(ctx, PollService_GetResults_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pollServiceClient) WatchPoll(ctx context.Context, in *WatchPollRequest, opts ...grpc.CallOption) (PollService_WatchPollClient, error) {
+	stream, err := c.cc.NewStream(ctx, &PollService_ServiceDesc.Streams[0], PollService_WatchPoll_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &pollServiceWatchPollClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type PollService_WatchPollClient interface {
+	Recv() (*PollUpdate, error)
+	grpc.ClientStream
+}
+
+type pollServiceWatchPollClient struct {
+	grpc.ClientStream
+}
+
+func (x *pollServiceWatchPollClient) Recv() (*PollUpdate, error) {
+	m := new(PollUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// PollServiceServer is the server API for PollService service.
+// All implementations must embed UnimplementedPollServiceServer
+// for forward compatibility
+type PollServiceServer interface {
+	// GetPoll returns a poll by contract address
+	GetPoll(context.Context, *GetPollRequest) (*Poll, error)
+	// ListPolls lists indexed polls, newest first
+	ListPolls(context.Context, *ListPollsRequest) (*ListPollsResponse, error)
+	// ListVotes lists a poll's vote commitments in commit order
+	ListVotes(context.Context, *ListVotesRequest) (*ListVotesResponse, error)
+	// GetResults returns the tallied results of a poll. It fails with
+	// FAILED_PRECONDITION until the poll is tallied.
+	GetResults(context.Context, *GetResultsRequest) (*Result, error)
+	// WatchPoll sends the poll's current state, then one update per event the
+	// indexer stores for it. The stream ends once the poll is tallied.
+	WatchPoll(*WatchPollRequest, PollService_WatchPollServer) error
+	mustEmbedUnimplementedPollServiceServer()
+}
+
+// UnimplementedPollServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPollServiceServer struct {
+}
+
+func (UnimplementedPollServiceServer) GetPoll(context.Context, *GetPollRequest) (*Poll, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPoll not implemented")
+}
+func (UnimplementedPollServiceServer) ListPolls(context.Context, *ListPollsRequest) (*ListPollsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListPolls not implemented")
+}
+func (UnimplementedPollServiceServer) ListVotes(context.Context, *ListVotesRequest) (*ListVotesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListVotes not implemented")
+}
+func (UnimplementedPollServiceServer) GetResults(context.Context, *GetResultsRequest) (*Result, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetResults not implemented")
+}
+func (UnimplementedPollServiceServer) WatchPoll(*WatchPollRequest, PollService_WatchPollServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPoll not implemented")
+}
+func (UnimplementedPollServiceServer) mustEmbedUnimplementedPollServiceServer() {}
+
+// UnsafePollServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PollServiceServer will
+// result in compilation errors.
+type UnsafePollServiceServer interface {
+	mustEmbedUnimplementedPollServiceServer()
+}
+
+func RegisterPollServiceServer(s grpc.ServiceRegistrar, srv PollServiceServer) {
+	s.RegisterService(&PollService_ServiceDesc, srv)
+}
+
+func _PollService_GetPoll_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).GetPoll(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_GetPoll_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).GetPoll(ctx, req.(*GetPollRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_ListPolls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPollsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).ListPolls(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_ListPolls_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).ListPolls(ctx, req.(*ListPollsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_ListVotes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListVotesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).ListVotes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_ListVotes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).ListVotes(ctx, req.(*ListVotesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_GetResults_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetResultsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PollServiceServer).GetResults(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PollService_GetResults_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PollServiceServer).GetResults(ctx, req.(*GetResultsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PollService_WatchPoll_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPollRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PollServiceServer).WatchPoll(m, &pollServiceWatchPollServer{stream})
+}
+
+type PollService_WatchPollServer interface {
+	Send(*PollUpdate) error
+	grpc.ServerStream
+}
+
+type pollServiceWatchPollServer struct {
+	grpc.ServerStream
+}
+
+func (x *pollServiceWatchPollServer) Send(m *PollUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// PollService_ServiceDesc is the grpc.ServiceDesc for PollService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PollService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "blockchainqa.poll.v1.PollService",
+	HandlerType: (*PollServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPoll",
+			Handler:    _PollService_GetPoll_Handler,
+		},
+		{
+			MethodName: "ListPolls",
+			Handler:    _PollService_ListPolls_Handler,
+		},
+		{
+			MethodName: "ListVotes",
+			Handler:    _PollService_ListVotes_Handler,
+		},
+		{
+			MethodName: "GetResults",
+			Handler:    _PollService_GetResults_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchPoll",
+			Handler:       _PollService_WatchPoll_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "api/poll/v1/poll.proto",
+}
@@ -3,18 +3,29 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
 	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/grpcserver"
 	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/handlers"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/openapi"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
 	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
 	"github.com/gofiber/fiber/v2/middleware/cors"
 	"github.com/gofiber/fiber/v2/middleware/logger"
 	"github.com/gofiber/fiber/v2/middleware/recover"
+	"github.com/gofiber/fiber/v2/middleware/requestid"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -52,43 +63,122 @@ func main() {
 		log.Println("Connected to Redis")
 	}
 
+	// Connect to the node of every configured chain (optional, for at_block
+	// queries). Factory addresses let GET /api/polls/:address read polls
+	// the indexer has not stored yet from the chain.
+	chainConfigs, err := blockchain.ChainsFromEnv()
+	if err != nil {
+		log.Printf("Warning: invalid chain configuration, chain reads are disabled: %v", err)
+		chainConfigs = nil
+	}
+	chains := blockchain.NewChains(ctx, chainConfigs)
+	log.Printf("Serving chains %v (default %d)\n", chains.IDs(), chains.Default)
+
 	// Create Fiber app
+	// Errors returned by handlers and middleware, including unmatched
+	// routes and recovered panics, are rendered in the shared error envelope
 	app := fiber.New(fiber.Config{
 		AppName:      "Blockchain QA API",
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
+		ErrorHandler: apierror.Handler,
 	})
 
-	// Middleware
+	// Middleware. The request ID comes first so every error body carries it.
+	app.Use(requestid.New(requestid.Config{ContextKey: apierror.RequestIDKey}))
 	app.Use(recover.New())
 	app.Use(logger.New())
+
+	// Metrics are recorded per route template and exposed at /metrics
+	apiMetrics := instrumentation.NewAPI(prometheus.DefaultRegisterer)
+	app.Use(handlers.Metrics(apiMetrics))
+	app.Get("/metrics", adaptor.HTTPHandler(instrumentation.Handler()))
+	corsOrigins := os.Getenv("CORS_ORIGINS")
+	if corsOrigins == "" {
+		corsOrigins = "*"
+	}
 	app.Use(cors.New(cors.Config{
-		AllowOrigins: "*",
-		AllowMethods: "GET,POST,PUT,DELETE,OPTIONS",
-		AllowHeaders: "Origin,Content-Type,Accept,Authorization",
+		AllowOrigins:  corsOrigins,
+		AllowMethods:  "GET,POST,PUT,DELETE,OPTIONS",
+		AllowHeaders:  "Origin,Content-Type,Accept,Authorization,X-API-Key,X-Request-ID,If-None-Match,If-Modified-Since",
+		ExposeHeaders: "Retry-After,X-RateLimit-Limit,X-RateLimit-Remaining,X-Request-ID,ETag,Last-Modified",
 	}))
 
+	// API keys: make sure the bootstrap admin key exists
+	if err := auth.BootstrapAdminKey(ctx, db); err != nil {
+		log.Fatalf("Failed to bootstrap admin API key: %v", err)
+	}
+
+	// Every /api request is authenticated (optionally) and rate limited
+	// per client IP, and per key as well when authenticated
+	limiter := auth.NewLimiter(redisClient)
+	limit := auth.DefaultLimitFromEnv()
+	authRequired := os.Getenv("API_AUTH_REQUIRED") == "true"
+	apiMiddleware := []fiber.Handler{
+		auth.Authenticate(db),
+		auth.RateLimit(limiter, limit),
+		handlers.ChainScope(chains),
+	}
+	if authRequired {
+		apiMiddleware = append(apiMiddleware, auth.RequireScope(auth.ScopeRead))
+	}
+
+	// Vote fields are redacted per poll state and caller scope
+	votePolicy := privacy.PolicyFromEnv()
+
 	// Initialize handlers
-	pollHandler := handlers.NewPollHandler(db, redisClient)
-
-	// Routes
-	api := app.Group("/api")
-
-	// Poll routes
-	polls := api.Group("/polls")
-	polls.Get("/", pollHandler.ListPolls)
-	polls.Get("/:address", pollHandler.GetPoll)
-	polls.Get("/:address/votes", pollHandler.GetPollVotes)
-	polls.Get("/:address/results", pollHandler.GetPollResults)
-	polls.Get("/:address/stats", pollHandler.GetVoteCount)
-
-	// Health check
-	app.Get("/health", func(c *fiber.Ctx) error {
-		return c.JSON(fiber.Map{
-			"status": "ok",
-			"time":   time.Now().UTC().Format(time.RFC3339),
-		})
-	})
+	pollHandler := handlers.NewPollHandler(db, redisClient, chains, apiMetrics, votePolicy)
+	chainHandler := handlers.NewChainHandler(chains)
+	adminHandler := handlers.NewAdminHandler(db)
+	merkleHandler := handlers.NewMerkleHandler(db)
+	annotationHandler := handlers.NewAnnotationHandler(db, redisClient)
+	lookupHandler := handlers.NewLookupHandler(db, votePolicy)
+	statsHandler := handlers.NewStatsHandler(db)
+	webhookHandler := handlers.NewWebhookHandler(db)
+	indexerHandler := handlers.NewIndexerHandler(db, redisClient, chains)
+	healthHandler := handlers.NewHealthHandler(db, redisClient)
+	graphqlHandler, err := handlers.NewGraphQLHandler(db, votePolicy)
+	if err != nil {
+		log.Fatalf("Failed to build GraphQL schema: %v", err)
+	}
+
+	// Routes are documented as they are registered; the resulting
+	// OpenAPI document is served at /api/openapi.json
+	doc := openapi.New("Blockchain QA API", "1.0.0")
+	app.Get("/api/openapi.json", doc.Handler)
+
+	api := openapi.NewRouter(app.Group("/api", apiMiddleware...), "/api", doc)
+
+	h := &apiHandlers{
+		poll:       pollHandler,
+		admin:      adminHandler,
+		merkle:     merkleHandler,
+		annotation: annotationHandler,
+		lookup:     lookupHandler,
+		stats:      statsHandler,
+		webhook:    webhookHandler,
+		indexer:    indexerHandler,
+	}
+
+	// Every chain-scoped route serves the default chain at /api and the
+	// chain it names under /api/chains/:chainId; shared resources are only
+	// served at /api
+	api.Get("/chains", handlers.ListChainsOp, chainHandler.ListChains)
+	registerGlobalAPI(api, h)
+	registerAPI(api, h)
+	scoped := api.Scope("/chains/:chainId", handlers.ChainIDParam, "OnChain", handlers.ChainScope(chains))
+	registerAPI(scoped, h)
+	scoped.Post("/graphql", handlers.GraphQLOp, graphqlHandler.Query)
+
+	// GraphQL shares the /api authentication and rate limits
+	root := openapi.NewRouter(app, "", doc)
+	root.Post("/graphql", handlers.GraphQLOp, append(apiMiddleware, graphqlHandler.Query)...)
+
+	// Health checks: /health is liveness only; /ready and /health/details
+	// probe dependencies and return 503 when the service is degraded
+	root.Get("/health", handlers.HealthOp, healthHandler.Health)
+	root.Get("/ready", handlers.ReadyOp, healthHandler.Ready)
+	root.Get("/health/details", handlers.HealthDetailsOp, healthHandler.Details)
 
 	// Start server in a goroutine
 	port := os.Getenv("PORT")
@@ -103,6 +193,30 @@ func main() {
 		}
 	}()
 
+	// gRPC shares the database, API keys, rate limits and vote redaction
+	grpcPort := os.Getenv("GRPC_PORT")
+	if grpcPort == "" {
+		grpcPort = "50051"
+	}
+	grpcServer := grpcserver.New(db, grpcserver.Options{
+		Policy:       votePolicy,
+		Limiter:      limiter,
+		Limit:        limit,
+		AuthRequired: authRequired,
+		Chains:       chains,
+	})
+	grpcListener, err := net.Listen("tcp", ":"+grpcPort)
+	if err != nil {
+		log.Fatalf("Failed to listen for gRPC: %v", err)
+	}
+
+	go func() {
+		log.Printf("Starting gRPC server on port %s\n", grpcPort)
+		if err := grpcServer.Serve(grpcListener); err != nil {
+			log.Fatalf("Failed to start gRPC server: %v", err)
+		}
+	}()
+
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
@@ -115,5 +229,18 @@ func main() {
 		log.Printf("Error during shutdown: %v", err)
 	}
 
+	// WatchPoll streams only end when their poll is tallied, so give them
+	// a moment and then close them
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		grpcServer.Stop()
+	}
+
 	log.Println("Server stopped")
 }
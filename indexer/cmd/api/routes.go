@@ -0,0 +1,83 @@
+package main
+
+import (
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/handlers"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/openapi"
+)
+
+// apiHandlers are the handlers behind the /api routes
+type apiHandlers struct {
+	poll       *handlers.PollHandler
+	admin      *handlers.AdminHandler
+	merkle     *handlers.MerkleHandler
+	annotation *handlers.AnnotationHandler
+	lookup     *handlers.LookupHandler
+	stats      *handlers.StatsHandler
+	webhook    *handlers.WebhookHandler
+	indexer    *handlers.IndexerHandler
+}
+
+// registerGlobalAPI registers the /api routes on resources every chain
+// shares: API keys and voter trees. They are registered once, outside the
+// chain scope.
+func registerGlobalAPI(api *openapi.Router, h *apiHandlers) {
+	// Voter eligibility trees; registering a list requires the admin scope
+	merkle := api.Group("/merkle")
+	merkle.Post("/", handlers.RegisterVoterListOp, auth.RequireScope(auth.ScopeAdmin), h.merkle.RegisterVoterList)
+	merkle.Get("/:root", handlers.GetMerkleTreeOp, h.merkle.GetMerkleTree)
+	merkle.Get("/:root/proof/:voter", handlers.GetMerkleProofOp, h.merkle.GetMerkleProof)
+
+	// Admin routes require an API key with the admin scope
+	admin := api.Group("/admin", auth.RequireScope(auth.ScopeAdmin))
+	admin.Post("/keys", handlers.CreateAPIKeyOp, h.admin.CreateAPIKey)
+	admin.Get("/keys", handlers.ListAPIKeysOp, h.admin.ListAPIKeys)
+	admin.Delete("/keys/:id", handlers.RevokeAPIKeyOp, h.admin.RevokeAPIKey)
+}
+
+// registerAPI registers the /api routes on chain-scoped resources. It runs
+// once for the default chain and once under /api/chains/:chainId.
+func registerAPI(api *openapi.Router, h *apiHandlers) {
+	// Poll routes
+	polls := api.Group("/polls")
+	polls.Get("/", handlers.ListPollsOp, h.poll.ListPolls)
+	polls.Get("/overdue", handlers.ListOverduePollsOp, h.poll.ListOverduePolls)
+	polls.Post("/batch", handlers.GetPollsBatchOp, h.poll.GetPollsBatch)
+	polls.Get("/:address", handlers.GetPollOp, h.poll.GetPoll)
+	polls.Get("/:address/votes", handlers.GetPollVotesOp, h.poll.GetPollVotes)
+	polls.Get("/:address/results", handlers.GetPollResultsOp, h.poll.GetPollResults)
+	polls.Get("/:address/analytics", handlers.GetPollAnalyticsOp, h.poll.GetPollAnalytics)
+	polls.Get("/:address/timeseries", handlers.GetPollTimeseriesOp, h.poll.GetPollTimeseries)
+	polls.Get("/:address/export", handlers.ExportPollOp, h.poll.ExportPoll)
+	polls.Get("/:address/stats", handlers.GetVoteCountOp, h.poll.GetVoteCount)
+
+	// Poll annotations; changing them requires the annotate scope
+	annotate := auth.RequireScope(auth.ScopeAnnotate)
+	polls.Get("/:address/annotations", handlers.GetPollAnnotationOp, h.annotation.GetAnnotation)
+	polls.Put("/:address/annotations", handlers.SetPollAnnotationOp, annotate, h.annotation.SetAnnotation)
+	polls.Delete("/:address/annotations", handlers.DeletePollAnnotationOp, annotate, h.annotation.DeleteAnnotation)
+	polls.Post("/:address/annotations/tags", handlers.AddPollTagsOp, annotate, h.annotation.AddTags)
+	polls.Delete("/:address/annotations/tags/:tag", handlers.RemovePollTagOp, annotate, h.annotation.RemoveTag)
+
+	// Platform-wide statistics
+	api.Get("/stats", handlers.GetPlatformStatsOp, h.stats.GetPlatformStats)
+
+	// Lookups by transaction hash and block number
+	api.Get("/tx/:hash", handlers.GetTransactionOp, h.lookup.GetTransaction)
+	api.Get("/blocks/:number", handlers.GetBlockEventsOp, h.lookup.GetBlockEvents)
+
+	// Indexer control and webhooks act on the chain's own rows and require
+	// an API key with the admin scope
+	admin := api.Group("/admin", auth.RequireScope(auth.ScopeAdmin))
+	admin.Get("/indexer", handlers.GetIndexerStatusOp, h.indexer.GetIndexerStatus)
+	admin.Post("/indexer/pause", handlers.PauseIndexerOp, h.indexer.PauseIndexer)
+	admin.Post("/indexer/resume", handlers.ResumeIndexerOp, h.indexer.ResumeIndexer)
+	admin.Post("/indexer/reindex", handlers.ReindexOp, h.indexer.Reindex)
+	admin.Get("/indexer/failed-events", handlers.ListFailedEventsOp, h.indexer.ListFailedEvents)
+	admin.Post("/cache/flush", handlers.FlushCacheOp, h.indexer.FlushCache)
+	admin.Post("/webhooks", handlers.CreateWebhookOp, h.webhook.CreateWebhook)
+	admin.Get("/webhooks", handlers.ListWebhooksOp, h.webhook.ListWebhooks)
+	admin.Delete("/webhooks/:id", handlers.DeleteWebhookOp, h.webhook.DeleteWebhook)
+	admin.Get("/webhooks/:id/deliveries", handlers.ListWebhookDeliveriesOp, h.webhook.ListWebhookDeliveries)
+	admin.Post("/webhooks/deliveries/:id/replay", handlers.ReplayWebhookDeliveryOp, h.webhook.ReplayWebhookDelivery)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"os"
 	"os/signal"
@@ -9,7 +10,10 @@ import (
 
 	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
 	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/webhook"
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func main() {
@@ -29,46 +33,67 @@ func main() {
 	defer db.Close()
 	log.Println("Connected to database")
 
-	// Connect to Ethereum node
-	client, err := blockchain.NewClient(ctx)
+	// The (chain, factory) pairs to watch, from CHAINS or the legacy
+	// RPC_URL and POLL_FACTORY_ADDRESS
+	chains, err := blockchain.ChainsFromEnv()
 	if err != nil {
-		log.Fatalf("Failed to connect to Ethereum node: %v", err)
+		log.Fatalf("Invalid chain configuration: %v", err)
 	}
-	log.Printf("Connected to Ethereum node (Chain ID: %s)\n", client.ChainID.String())
-
-	// Get PollFactory address from environment
-	pollFactory := os.Getenv("POLL_FACTORY_ADDRESS")
-	if pollFactory == "" {
+	if os.Getenv("CHAINS") == "" && os.Getenv("POLL_FACTORY_ADDRESS") == "" {
 		log.Fatal("POLL_FACTORY_ADDRESS environment variable is required")
 	}
 
-	// Get start block from environment (default to 0)
-	startBlock := uint64(0)
-	// TODO: Parse START_BLOCK from environment if provided
+	// Expose Prometheus metrics
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = ":9090"
+	}
+	indexerMetrics := instrumentation.NewIndexer(prometheus.DefaultRegisterer)
+	go instrumentation.Serve(ctx, metricsAddr)
 
-	// Create and start event listener
-	listener := blockchain.NewListener(client, db, pollFactory, startBlock)
+	// Each pair gets its own node connection and listener
+	listeners := make([]*blockchain.Listener, len(chains))
+	for i := range chains {
+		client, err := blockchain.NewClientForChain(ctx, &chains[i])
+		if err != nil {
+			log.Fatalf("Failed to connect to Ethereum node: %v", err)
+		}
+		log.Printf("Connected to Ethereum node (Chain ID: %d) for factory %s\n", chains[i].ChainID, chains[i].Factory.Hex())
+
+		listeners[i] = blockchain.NewListener(client, db, chains[i], indexerMetrics)
+	}
+
+	// Deliver queued webhooks
+	dispatcher := webhook.NewDispatcher(db, webhook.ConfigFromEnv(), indexerMetrics)
+	go dispatcher.Run(ctx)
 
 	// Handle graceful shutdown
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, os.Interrupt, syscall.SIGTERM)
 
-	// Start listener in goroutine
-	errChan := make(chan error, 1)
-	go func() {
-		errChan <- listener.Start(ctx)
-	}()
+	// Start listeners in goroutines; the indexer stops when any of them
+	// fails so it can be restarted as a whole
+	errChan := make(chan error, len(listeners))
+	for _, listener := range listeners {
+		go func(listener *blockchain.Listener) {
+			err := listener.Start(ctx)
+			if err != nil && err != context.Canceled {
+				err = fmt.Errorf("listener %s: %w", listener.ID(), err)
+			}
+			errChan <- err
+		}(listener)
+	}
 
 	// Wait for shutdown signal or error
 	select {
 	case <-quit:
 		log.Println("Received shutdown signal")
-		cancel()
 	case err := <-errChan:
 		if err != nil && err != context.Canceled {
 			log.Printf("Listener error: %v\n", err)
 		}
 	}
+	cancel()
 
 	log.Println("Indexer stopped")
 }
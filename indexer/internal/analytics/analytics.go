@@ -0,0 +1,115 @@
+// Package analytics derives summary statistics from poll vote counts
+package analytics
+
+import "math"
+
+// OptionShare is the vote count and share of one poll option
+type OptionShare struct {
+	Index      int     `json:"index"`
+	Option     string  `json:"option"`
+	Votes      int     `json:"votes"`
+	Percentage float64 `json:"percentage"` // Share of counted votes, 0-100
+}
+
+// Summary describes the outcome of a poll from its per-option counts
+type Summary struct {
+	Options []OptionShare `json:"options"`
+	// Winners holds the indexes of the leading options; empty until a vote is counted
+	Winners []int `json:"winners"`
+	Tie     bool  `json:"tie"`
+
+	CommittedVotes  int     `json:"committed_votes"`
+	CountedVotes    int     `json:"counted_votes"`
+	UnrevealedVotes int     `json:"unrevealed_votes"`
+	RevealRate      float64 `json:"reveal_rate"` // Counted votes over committed votes, 0-1
+
+	// Margin is the vote difference between the leader and the runner-up
+	Margin           int     `json:"margin"`
+	MarginPercentage float64 `json:"margin_percentage"`
+
+	// VotesToChange is the fewest unrevealed votes that would change the
+	// winners if they all went to one other option
+	VotesToChange int `json:"votes_to_change"`
+	// FlipShare is VotesToChange as a share of unrevealed votes; above 1
+	// the outcome is decided. Nil once no votes are left to reveal.
+	FlipShare *float64 `json:"flip_share"`
+	// Decided reports that unrevealed votes can no longer change the winners
+	Decided bool `json:"decided"`
+}
+
+// Summarize computes a Summary from per-option counts. When final is set
+// (results are tallied) unrevealed commitments can no longer be revealed
+// and the outcome is decided.
+func Summarize(options []string, counts []int, committed int, final bool) *Summary {
+	s := &Summary{
+		Options:        make([]OptionShare, len(options)),
+		Winners:        []int{},
+		CommittedVotes: committed,
+	}
+
+	for i, option := range options {
+		s.Options[i] = OptionShare{Index: i, Option: option}
+		if i < len(counts) {
+			s.Options[i].Votes = counts[i]
+			s.CountedVotes += counts[i]
+		}
+	}
+
+	if committed > s.CountedVotes {
+		s.UnrevealedVotes = committed - s.CountedVotes
+	}
+	if committed > 0 {
+		s.RevealRate = round(float64(s.CountedVotes)/float64(committed), 4)
+	}
+
+	// Leader and runner-up counts
+	first, second := 0, 0
+	for i := range s.Options {
+		votes := s.Options[i].Votes
+		if s.CountedVotes > 0 {
+			s.Options[i].Percentage = round(100*float64(votes)/float64(s.CountedVotes), 2)
+		}
+		switch {
+		case votes > first:
+			first, second = votes, first
+		case votes > second:
+			second = votes
+		}
+	}
+
+	if s.CountedVotes > 0 {
+		for _, option := range s.Options {
+			if option.Votes == first {
+				s.Winners = append(s.Winners, option.Index)
+			}
+		}
+		s.Tie = len(s.Winners) > 1
+		s.Margin = first - second
+		s.MarginPercentage = round(100*float64(s.Margin)/float64(s.CountedVotes), 2)
+	}
+
+	// A tie or an empty count is broken by a single vote; otherwise the
+	// runner-up needs the full margin to draw level
+	s.VotesToChange = s.Margin
+	if s.VotesToChange == 0 {
+		s.VotesToChange = 1
+	}
+
+	if final {
+		s.Decided = true
+		return s
+	}
+
+	if s.UnrevealedVotes > 0 {
+		share := round(float64(s.VotesToChange)/float64(s.UnrevealedVotes), 4)
+		s.FlipShare = &share
+	}
+	s.Decided = s.UnrevealedVotes < s.VotesToChange
+
+	return s
+}
+
+func round(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,130 @@
+// Package apierror defines the error envelope returned by every failed API
+// request and the stable error codes clients and tests can match on.
+package apierror
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Code is a stable, machine-readable error code
+type Code string
+
+// Error codes. Codes are part of the API contract: add new ones freely but
+// never rename or reuse an existing code.
+const (
+	InvalidRequest   Code = "INVALID_REQUEST"   // Malformed body or missing field
+	InvalidParameter Code = "INVALID_PARAMETER" // Query or path parameter out of range
+	InvalidAddress   Code = "INVALID_ADDRESS"   // Malformed Ethereum address
+	InvalidTxHash    Code = "INVALID_TX_HASH"   // Malformed transaction hash
+
+	Unauthorized Code = "UNAUTHORIZED" // Missing or unknown API key
+	Forbidden    Code = "FORBIDDEN"    // API key lacks the required scope
+	RateLimited  Code = "RATE_LIMITED"
+
+	NotFound           Code = "NOT_FOUND"       // Unknown route or resource without a specific code
+	ChainNotFound      Code = "CHAIN_NOT_FOUND" // The API does not serve the chain in the path
+	PollNotFound       Code = "POLL_NOT_FOUND"
+	ResultsNotFound    Code = "RESULTS_NOT_FOUND"
+	APIKeyNotFound     Code = "API_KEY_NOT_FOUND"
+	MerkleTreeNotFound Code = "MERKLE_TREE_NOT_FOUND"
+	WebhookNotFound    Code = "WEBHOOK_NOT_FOUND"
+	DeliveryNotFound   Code = "DELIVERY_NOT_FOUND"
+	TxNotFound         Code = "TRANSACTION_NOT_FOUND" // No indexed events in the transaction
+	VoterNotEligible   Code = "VOTER_NOT_ELIGIBLE"
+	PollActive         Code = "POLL_ACTIVE"       // The operation needs a closed poll
+	BlockNotIndexed    Code = "BLOCK_NOT_INDEXED" // at_block is past the indexer cursor
+
+	ChainUnavailable Code = "CHAIN_UNAVAILABLE" // The API has no Ethereum node connection
+
+	Timeout  Code = "TIMEOUT" // The request deadline expired
+	Internal Code = "INTERNAL_ERROR"
+)
+
+// Error describes a failed request
+type Error struct {
+	Code      Code   `json:"code"`
+	Message   string `json:"message"`
+	Details   any    `json:"details,omitempty"`
+	RequestID string `json:"request_id,omitempty"`
+}
+
+// Envelope is the response body returned for failed requests
+type Envelope struct {
+	Error Error `json:"error"`
+}
+
+// RequestIDKey is the fiber.Ctx locals key the request ID middleware stores the ID under
+const RequestIDKey = "requestid"
+
+// ParameterDetails names the parameter an INVALID_* error refers to
+type ParameterDetails struct {
+	Parameter string `json:"parameter"`
+}
+
+// Respond writes an error envelope with the given status
+func Respond(c *fiber.Ctx, status int, code Code, message string) error {
+	return RespondDetails(c, status, code, message, nil)
+}
+
+// RespondDetails writes an error envelope with structured details
+func RespondDetails(c *fiber.Ctx, status int, code Code, message string, details any) error {
+	requestID, _ := c.Locals(RequestIDKey).(string)
+	return c.Status(status).JSON(Envelope{Error: Error{
+		Code:      code,
+		Message:   message,
+		Details:   details,
+		RequestID: requestID,
+	}})
+}
+
+// BadRequest writes a 400 INVALID_REQUEST error
+func BadRequest(c *fiber.Ctx, message string) error {
+	return Respond(c, fiber.StatusBadRequest, InvalidRequest, message)
+}
+
+// InvalidParam writes a 400 error naming the offending parameter
+func InvalidParam(c *fiber.Ctx, code Code, parameter, message string) error {
+	return RespondDetails(c, fiber.StatusBadRequest, code, message, ParameterDetails{Parameter: parameter})
+}
+
+// NotFoundError writes a 404 error
+func NotFoundError(c *fiber.Ctx, code Code, message string) error {
+	return Respond(c, fiber.StatusNotFound, code, message)
+}
+
+// InternalError logs err and writes a 500 with message. Errors caused by the
+// request deadline are reported as 504 TIMEOUT instead.
+func InternalError(c *fiber.Ctx, err error, message string) error {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return Respond(c, fiber.StatusGatewayTimeout, Timeout, "request timed out")
+	}
+
+	requestID, _ := c.Locals(RequestIDKey).(string)
+	log.Printf("Error [%s] %s %s: %s: %v\n", requestID, c.Method(), c.Path(), message, err)
+	return Respond(c, fiber.StatusInternalServerError, Internal, message)
+}
+
+// Handler is the fiber ErrorHandler. It renders errors returned by
+// handlers and middleware, such as unmatched routes and recovered panics,
+// in the same envelope.
+func Handler(c *fiber.Ctx, err error) error {
+	var fe *fiber.Error
+	if errors.As(err, &fe) {
+		code := Internal
+		switch {
+		case fe.Code == fiber.StatusNotFound:
+			code = NotFound
+		case fe.Code == fiber.StatusRequestTimeout:
+			code = Timeout
+		case fe.Code < fiber.StatusInternalServerError:
+			code = InvalidRequest
+		}
+		return Respond(c, fe.Code, code, fe.Message)
+	}
+
+	return InternalError(c, err, "internal server error")
+}
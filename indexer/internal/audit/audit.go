@@ -0,0 +1,189 @@
+// Package audit assembles the per-poll audit bundle: poll metadata, raw
+// events, commitments and reveals, results, gas used and a consistency
+// check. Rows are streamed from the database straight into the output
+// format so large polls are never held in memory.
+package audit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+)
+
+// Export formats
+const (
+	FormatJSON = "json"
+	FormatCSV  = "csv"
+	FormatZIP  = "zip"
+)
+
+// Bundle documents the shape of the JSON export. The export itself is
+// written incrementally and never materialized as a Bundle.
+type Bundle struct {
+	Poll        *database.Poll     `json:"poll"`
+	Events      []*database.Event  `json:"events"`
+	Votes       []*database.Vote   `json:"votes"`
+	Result      *database.Result   `json:"result"`
+	Gas         *GasSummary        `json:"gas"`
+	Consistency *ConsistencyReport `json:"consistency"`
+}
+
+// GasSummary totals the gas used by the transactions that emitted the poll's events
+type GasSummary struct {
+	TotalGasUsed int64            `json:"total_gas_used"`
+	Transactions int              `json:"transactions"`
+	ByEvent      map[string]int64 `json:"by_event"`    // Attributed to the first event of each transaction
+	MissingGas   int              `json:"missing_gas"` // Transactions whose receipt was unavailable at index time
+}
+
+// Check is one consistency assertion over the indexed data
+type Check struct {
+	Name     string `json:"name"`
+	OK       bool   `json:"ok"`
+	Expected int64  `json:"expected"`
+	Actual   int64  `json:"actual"`
+}
+
+// ConsistencyReport is the indexer's own cross-check of events, votes and results
+type ConsistencyReport struct {
+	Status      string    `json:"status"` // "ok" or "inconsistent"
+	Checks      []Check   `json:"checks"`
+	GeneratedAt time.Time `json:"generated_at"`
+}
+
+// Sink writes one export format. Events and votes are pushed through the
+// emit callback one row at a time.
+type Sink interface {
+	WritePoll(poll *database.Poll) error
+	WriteEvents(stream func(emit func(*database.Event) error) error) error
+	WriteVotes(stream func(emit func(*database.Vote) error) error) error
+	WriteResult(result *database.Result) error
+	WriteSummary(gas *GasSummary, report *ConsistencyReport) error
+	Close() error
+}
+
+// Export streams the audit bundle for poll into sink and closes it. Votes
+// and events are redacted by policy for viewer; the consistency checks run
+// on the unredacted rows.
+func Export(ctx context.Context, db *database.DB, poll *database.Poll, sink Sink, policy privacy.Policy, viewer privacy.Viewer) error {
+	c := newChecker()
+
+	if err := sink.WritePoll(poll); err != nil {
+		return err
+	}
+
+	err := sink.WriteEvents(func(emit func(*database.Event) error) error {
+		return db.StreamPollEvents(ctx, poll, func(event *database.Event) error {
+			c.event(event)
+			return emit(policy.Event(event, poll.State, viewer))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export events: %w", err)
+	}
+
+	err = sink.WriteVotes(func(emit func(*database.Vote) error) error {
+		return db.StreamVotesByPoll(ctx, poll.ContractAddress, func(vote *database.Vote) error {
+			c.vote(vote)
+			return emit(policy.Vote(vote, poll.State, viewer))
+		})
+	})
+	if err != nil {
+		return fmt.Errorf("failed to export votes: %w", err)
+	}
+
+	result, err := db.GetResultByPoll(ctx, poll.ContractAddress)
+	if err != nil {
+		return err
+	}
+	if err := sink.WriteResult(result); err != nil {
+		return err
+	}
+
+	if err := sink.WriteSummary(c.gas, c.report(poll, result)); err != nil {
+		return err
+	}
+
+	return sink.Close()
+}
+
+// checker accumulates counts while rows stream past
+type checker struct {
+	events   map[string]int64
+	votes    int64
+	revealed int64
+	gas      *GasSummary
+	txs      map[string]bool
+}
+
+func newChecker() *checker {
+	return &checker{
+		events: make(map[string]int64),
+		gas:    &GasSummary{ByEvent: make(map[string]int64)},
+		txs:    make(map[string]bool),
+	}
+}
+
+func (c *checker) event(event *database.Event) {
+	c.events[event.EventName]++
+
+	// Gas is per transaction; count it once even if it emitted several events
+	if c.txs[event.TransactionHash] {
+		return
+	}
+	c.txs[event.TransactionHash] = true
+	c.gas.Transactions++
+
+	if event.GasUsed == nil {
+		c.gas.MissingGas++
+		return
+	}
+	c.gas.TotalGasUsed += *event.GasUsed
+	c.gas.ByEvent[event.EventName] += *event.GasUsed
+}
+
+func (c *checker) vote(vote *database.Vote) {
+	c.votes++
+	if vote.Revealed {
+		c.revealed++
+	}
+}
+
+func (c *checker) report(poll *database.Poll, result *database.Result) *ConsistencyReport {
+	r := &ConsistencyReport{Status: "ok", GeneratedAt: time.Now().UTC()}
+	add := func(name string, expected, actual int64) {
+		ok := expected == actual
+		r.Checks = append(r.Checks, Check{Name: name, OK: ok, Expected: expected, Actual: actual})
+		if !ok {
+			r.Status = "inconsistent"
+		}
+	}
+	flag := func(b bool) int64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+
+	add("VoteCommitted events match indexed votes", c.events["VoteCommitted"], c.votes)
+	add("VoteRevealed events match revealed votes", c.events["VoteRevealed"], c.revealed)
+	add("PollClosed event matches poll state", flag(poll.State != "active"), flag(c.events["PollClosed"] > 0))
+	add("ResultsTallied event matches stored result", c.events["ResultsTallied"], flag(result != nil))
+
+	if result != nil {
+		var sum int64
+		for _, n := range result.VoteCounts {
+			sum += int64(n)
+		}
+		add("result counts sum to total votes", int64(result.TotalVotes), sum)
+		add("result total matches revealed votes", int64(result.TotalVotes), c.revealed)
+		if result.ProvisionalMatch != nil {
+			add("provisional tally matches result", 1, flag(*result.ProvisionalMatch))
+		}
+	}
+
+	return r
+}
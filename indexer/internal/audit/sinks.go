@@ -0,0 +1,325 @@
+package audit
+
+import (
+	"archive/zip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+)
+
+// NewSink returns the sink for format, writing to w
+func NewSink(format string, w io.Writer) (Sink, error) {
+	switch format {
+	case FormatJSON:
+		return &jsonSink{w: w, enc: json.NewEncoder(w)}, nil
+	case FormatCSV:
+		return newCSVSink(w), nil
+	case FormatZIP:
+		return &zipSink{zw: zip.NewWriter(w)}, nil
+	default:
+		return nil, fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// ContentType returns the MIME type of an export format
+func ContentType(format string) string {
+	switch format {
+	case FormatCSV:
+		return "text/csv"
+	case FormatZIP:
+		return "application/zip"
+	default:
+		return "application/json"
+	}
+}
+
+// jsonSink writes a single JSON object with the Bundle layout
+type jsonSink struct {
+	w   io.Writer
+	enc *json.Encoder
+}
+
+func (s *jsonSink) field(name string, v any) error {
+	if _, err := fmt.Fprintf(s.w, ",%q:", name); err != nil {
+		return err
+	}
+	return s.enc.Encode(v)
+}
+
+func (s *jsonSink) WritePoll(poll *database.Poll) error {
+	if _, err := io.WriteString(s.w, `{"poll":`); err != nil {
+		return err
+	}
+	return s.enc.Encode(poll)
+}
+
+// jsonArray writes a JSON array field whose elements come from stream
+func jsonArray[T any](s *jsonSink, name string, stream func(emit func(T) error) error) error {
+	if _, err := fmt.Fprintf(s.w, ",%q:[", name); err != nil {
+		return err
+	}
+
+	first := true
+	err := stream(func(item T) error {
+		if !first {
+			if _, err := io.WriteString(s.w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		return s.enc.Encode(item)
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(s.w, "]")
+	return err
+}
+
+func (s *jsonSink) WriteEvents(stream func(emit func(*database.Event) error) error) error {
+	return jsonArray(s, "events", stream)
+}
+
+func (s *jsonSink) WriteVotes(stream func(emit func(*database.Vote) error) error) error {
+	return jsonArray(s, "votes", stream)
+}
+
+func (s *jsonSink) WriteResult(result *database.Result) error {
+	return s.field("result", result)
+}
+
+func (s *jsonSink) WriteSummary(gas *GasSummary, report *ConsistencyReport) error {
+	if err := s.field("gas", gas); err != nil {
+		return err
+	}
+	return s.field("consistency", report)
+}
+
+func (s *jsonSink) Close() error {
+	_, err := io.WriteString(s.w, "}\n")
+	return err
+}
+
+// csvColumns are shared by every row of the single-file CSV export.
+// The record column says which part of the bundle a row belongs to;
+// columns that do not apply to a record are left empty.
+var csvColumns = []string{
+	"record", "block_number", "log_index", "transaction_hash", "name",
+	"voter", "commitment", "choice", "revealed", "committed_at", "revealed_at",
+	"gas_used", "data",
+}
+
+// csvSink writes the whole bundle as one CSV table
+type csvSink struct {
+	w *csv.Writer
+}
+
+func newCSVSink(w io.Writer) *csvSink {
+	return &csvSink{w: csv.NewWriter(w)}
+}
+
+func (s *csvSink) row(fields map[string]string) error {
+	record := make([]string, len(csvColumns))
+	for i, col := range csvColumns {
+		record[i] = fields[col]
+	}
+	return s.w.Write(record)
+}
+
+func (s *csvSink) jsonRow(record string, fields map[string]string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	fields["record"] = record
+	fields["data"] = string(data)
+	return s.row(fields)
+}
+
+func (s *csvSink) WritePoll(poll *database.Poll) error {
+	if err := s.w.Write(csvColumns); err != nil {
+		return err
+	}
+	return s.jsonRow("poll", map[string]string{
+		"block_number":     formatInt(poll.BlockNumber),
+		"transaction_hash": poll.TransactionHash,
+		"name":             poll.State,
+	}, poll)
+}
+
+func (s *csvSink) WriteEvents(stream func(emit func(*database.Event) error) error) error {
+	return stream(func(event *database.Event) error {
+		return s.row(map[string]string{
+			"record":           "event",
+			"block_number":     formatInt(event.BlockNumber),
+			"log_index":        strconv.Itoa(event.LogIndex),
+			"transaction_hash": event.TransactionHash,
+			"name":             event.EventName,
+			"gas_used":         formatIntPtr(event.GasUsed),
+			"data":             event.EventData,
+		})
+	})
+}
+
+func (s *csvSink) WriteVotes(stream func(emit func(*database.Vote) error) error) error {
+	return stream(func(vote *database.Vote) error {
+		return s.row(voteFields(vote))
+	})
+}
+
+func (s *csvSink) WriteResult(result *database.Result) error {
+	if result == nil {
+		return nil
+	}
+	return s.jsonRow("result", map[string]string{
+		"block_number":     formatInt(result.BlockNumber),
+		"transaction_hash": result.TransactionHash,
+	}, result)
+}
+
+func (s *csvSink) WriteSummary(gas *GasSummary, report *ConsistencyReport) error {
+	if err := s.jsonRow("gas", map[string]string{"gas_used": formatInt(gas.TotalGasUsed)}, gas); err != nil {
+		return err
+	}
+	for _, check := range report.Checks {
+		if err := s.jsonRow("check", map[string]string{"name": check.Name}, check); err != nil {
+			return err
+		}
+	}
+	return s.jsonRow("consistency", map[string]string{"name": report.Status}, report)
+}
+
+func (s *csvSink) Close() error {
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// zipSink writes one file per section: JSON for single documents and
+// CSV for the event and vote tables
+type zipSink struct {
+	zw *zip.Writer
+}
+
+func (s *zipSink) writeJSON(name string, v any) error {
+	f, err := s.zw.Create(name)
+	if err != nil {
+		return err
+	}
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
+
+func (s *zipSink) WritePoll(poll *database.Poll) error {
+	return s.writeJSON("poll.json", poll)
+}
+
+func (s *zipSink) WriteEvents(stream func(emit func(*database.Event) error) error) error {
+	f, err := s.zw.Create("events.csv")
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.Write([]string{"block_number", "log_index", "transaction_hash", "block_hash", "contract_address", "event_name", "gas_used", "event_data"}); err != nil {
+		return err
+	}
+
+	err = stream(func(event *database.Event) error {
+		return w.Write([]string{
+			formatInt(event.BlockNumber), strconv.Itoa(event.LogIndex), event.TransactionHash,
+			event.BlockHash, event.ContractAddress.Hex(), event.EventName,
+			formatIntPtr(event.GasUsed), event.EventData,
+		})
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+// zipVoteColumns are the columns of votes.csv
+var zipVoteColumns = []string{
+	"voter", "commitment", "choice", "revealed", "committed_at", "revealed_at",
+	"revealed_block", "block_number", "transaction_hash",
+}
+
+func (s *zipSink) WriteVotes(stream func(emit func(*database.Vote) error) error) error {
+	f, err := s.zw.Create("votes.csv")
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if err := w.Write(zipVoteColumns); err != nil {
+		return err
+	}
+
+	err = stream(func(vote *database.Vote) error {
+		fields := voteFields(vote)
+		record := make([]string, len(zipVoteColumns))
+		for i, col := range zipVoteColumns {
+			record[i] = fields[col]
+		}
+		return w.Write(record)
+	})
+	if err != nil {
+		return err
+	}
+
+	w.Flush()
+	return w.Error()
+}
+
+func (s *zipSink) WriteResult(result *database.Result) error {
+	return s.writeJSON("result.json", result)
+}
+
+func (s *zipSink) WriteSummary(gas *GasSummary, report *ConsistencyReport) error {
+	if err := s.writeJSON("gas.json", gas); err != nil {
+		return err
+	}
+	return s.writeJSON("consistency.json", report)
+}
+
+func (s *zipSink) Close() error {
+	return s.zw.Close()
+}
+
+// voteFields renders a vote as named CSV fields
+func voteFields(vote *database.Vote) map[string]string {
+	fields := map[string]string{
+		"record":           "vote",
+		"voter":            vote.Voter.Hex(),
+		"commitment":       vote.Commitment,
+		"revealed":         strconv.FormatBool(vote.Revealed),
+		"committed_at":     vote.CommittedAt.UTC().Format(time.RFC3339),
+		"revealed_block":   formatIntPtr(vote.RevealedBlock),
+		"block_number":     formatInt(vote.BlockNumber),
+		"transaction_hash": vote.TransactionHash,
+	}
+	if vote.Choice != nil {
+		fields["choice"] = strconv.Itoa(*vote.Choice)
+	}
+	if vote.RevealedAt != nil {
+		fields["revealed_at"] = vote.RevealedAt.UTC().Format(time.RFC3339)
+	}
+	return fields
+}
+
+func formatInt(n int64) string {
+	return strconv.FormatInt(n, 10)
+}
+
+func formatIntPtr(n *int64) string {
+	if n == nil {
+		return ""
+	}
+	return formatInt(*n)
+}
@@ -0,0 +1,196 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Scopes granted to API keys. Admin implies every scope; annotate, which
+// allows labelling polls, implies read.
+const (
+	ScopeRead     = "read"
+	ScopeAnnotate = "annotate"
+	ScopeAdmin    = "admin"
+)
+
+// keyPrefix marks plaintext keys so they are recognizable in logs and configs
+const keyPrefix = "bqa_"
+
+// localsKey is where the authenticated key is stored on the request context
+const localsKey = "api_key"
+
+// touchInterval is how stale a key's last_used_at may get before a request
+// records its use again
+const touchInterval = time.Minute
+
+// touched holds when this process last recorded each key's use, by key ID,
+// so a burst of requests with one key starts a single update
+var touched sync.Map
+
+// GenerateKey creates a new random API key and returns its plaintext and hash
+func GenerateKey() (plaintext, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+
+	plaintext = keyPrefix + base64.RawURLEncoding.EncodeToString(buf)
+	return plaintext, HashKey(plaintext), nil
+}
+
+// HashKey returns the stored form of a plaintext key.
+// Keys are high-entropy random values, so a plain SHA-256 is sufficient.
+func HashKey(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// DisplayPrefix returns the non-secret leading part of a key used to identify it
+func DisplayPrefix(plaintext string) string {
+	if len(plaintext) > 12 {
+		return plaintext[:12]
+	}
+	return plaintext
+}
+
+// HasScope reports whether a key grants the requested scope
+func HasScope(key *database.APIKey, scope string) bool {
+	if key == nil {
+		return false
+	}
+	for _, s := range key.Scopes {
+		if s == scope || s == ScopeAdmin || (s == ScopeAnnotate && scope == ScopeRead) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidScope reports whether scope is a known scope name
+func ValidScope(scope string) bool {
+	return scope == ScopeRead || scope == ScopeAnnotate || scope == ScopeAdmin
+}
+
+// KeyFromContext returns the API key that authenticated the request, if any
+func KeyFromContext(c *fiber.Ctx) *database.APIKey {
+	key, _ := c.Locals(localsKey).(*database.APIKey)
+	return key
+}
+
+// BootstrapAdminKey makes sure the key in ADMIN_API_KEY exists with the admin scope,
+// so a fresh deployment has a way to create further keys
+func BootstrapAdminKey(ctx context.Context, db *database.DB) error {
+	plaintext := os.Getenv("ADMIN_API_KEY")
+	if plaintext == "" {
+		return nil
+	}
+
+	key := &database.APIKey{
+		Name:      "bootstrap-admin",
+		KeyPrefix: DisplayPrefix(plaintext),
+		KeyHash:   HashKey(plaintext),
+		Scopes:    []string{ScopeAdmin},
+	}
+	return db.EnsureAPIKey(ctx, key)
+}
+
+// Authenticate resolves the API key supplied in the X-API-Key header or as a
+// bearer token. Requests without a key continue anonymously (use RequireScope
+// to reject them); requests with an unknown or revoked key are rejected.
+func Authenticate(db *database.DB) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		plaintext := extractKey(c)
+		if plaintext == "" {
+			return c.Next()
+		}
+
+		key, err := Lookup(c.UserContext(), db, plaintext)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to verify api key")
+		}
+		if key == nil {
+			return apierror.Respond(c, fiber.StatusUnauthorized, apierror.Unauthorized, "invalid api key")
+		}
+
+		c.Locals(localsKey, key)
+		return c.Next()
+	}
+}
+
+// Lookup returns the active key matching plaintext, or nil if there is
+// none, and records that it was used at most once per touchInterval
+func Lookup(ctx context.Context, db *database.DB, plaintext string) (*database.APIKey, error) {
+	key, err := db.GetActiveAPIKeyByHash(ctx, HashKey(plaintext))
+	if err != nil || key == nil {
+		return nil, err
+	}
+	if !claimTouch(key, time.Now()) {
+		return key, nil
+	}
+
+	// Usage tracking must not slow down or fail the request
+	go func(id int) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := db.TouchAPIKey(ctx, id); err != nil {
+			log.Printf("Warning: %v\n", err)
+		}
+	}(key.ID)
+
+	return key, nil
+}
+
+// claimTouch reports whether the caller should record key's use now: its
+// stored last use is older than touchInterval and no other request in this
+// process has claimed the update within that interval
+func claimTouch(key *database.APIKey, now time.Time) bool {
+	if key.LastUsedAt != nil && now.Sub(*key.LastUsedAt) < touchInterval {
+		return false
+	}
+
+	prev, loaded := touched.LoadOrStore(key.ID, now)
+	if !loaded {
+		return true
+	}
+	if now.Sub(prev.(time.Time)) < touchInterval {
+		return false
+	}
+	return touched.CompareAndSwap(key.ID, prev, now)
+}
+
+// RequireScope rejects requests whose API key does not grant scope
+func RequireScope(scope string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		key := KeyFromContext(c)
+		if key == nil {
+			return apierror.Respond(c, fiber.StatusUnauthorized, apierror.Unauthorized, "api key required")
+		}
+		if !HasScope(key, scope) {
+			return apierror.Respond(c, fiber.StatusForbidden, apierror.Forbidden, fmt.Sprintf("api key lacks %s scope", scope))
+		}
+		return c.Next()
+	}
+}
+
+func extractKey(c *fiber.Ctx) string {
+	if key := c.Get("X-API-Key"); key != "" {
+		return key
+	}
+	if authz := c.Get(fiber.HeaderAuthorization); strings.HasPrefix(authz, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(authz, "Bearer "))
+	}
+	return ""
+}
@@ -0,0 +1,258 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// Limit is a token bucket: Requests tokens refill evenly over Window,
+// and up to Requests may be spent in a burst
+type Limit struct {
+	Requests int
+	Window   time.Duration
+}
+
+// rate returns the refill rate in tokens per second
+func (l Limit) rate() float64 {
+	return float64(l.Requests) / l.Window.Seconds()
+}
+
+// Decision is the outcome of taking a token from a bucket
+type Decision struct {
+	Allowed    bool
+	Remaining  int
+	RetryAfter time.Duration
+}
+
+// Limiter takes tokens from named buckets
+type Limiter interface {
+	Take(ctx context.Context, bucket string, limit Limit) (Decision, error)
+}
+
+// NewLimiter returns a Redis-backed limiter when a client is available, so
+// limits are shared between API replicas, and an in-memory one otherwise
+func NewLimiter(client *redis.Client) Limiter {
+	memory := NewMemoryLimiter()
+	if client == nil {
+		return memory
+	}
+	return &redisLimiter{client: client, fallback: memory}
+}
+
+// DefaultLimitFromEnv reads RATE_LIMIT_REQUESTS and RATE_LIMIT_WINDOW (default 100 per 1m)
+func DefaultLimitFromEnv() Limit {
+	limit := Limit{Requests: 100, Window: time.Minute}
+
+	if v := os.Getenv("RATE_LIMIT_REQUESTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			limit.Requests = n
+		}
+	}
+	if v := os.Getenv("RATE_LIMIT_WINDOW"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			limit.Window = d
+		}
+	}
+
+	return limit
+}
+
+// RateLimit enforces a token bucket per client IP and, for authenticated
+// requests, per API key as well. Keys may carry their own request budget.
+func RateLimit(limiter Limiter, def Limit) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		decision, limit, err := Take(c.UserContext(), limiter, BucketsFor(KeyFromContext(c), c.IP(), def))
+		if err != nil {
+			// Fail open: rate limiting must not take the API down
+			log.Printf("Warning: rate limiter error: %v\n", err)
+			return c.Next()
+		}
+
+		c.Set("X-RateLimit-Limit", strconv.Itoa(limit.Requests))
+		c.Set("X-RateLimit-Remaining", strconv.Itoa(decision.Remaining))
+
+		if !decision.Allowed {
+			retryAfter := int(math.Ceil(decision.RetryAfter.Seconds()))
+			if retryAfter < 1 {
+				retryAfter = 1
+			}
+			c.Set(fiber.HeaderRetryAfter, strconv.Itoa(retryAfter))
+			return apierror.Respond(c, fiber.StatusTooManyRequests, apierror.RateLimited, "rate limit exceeded")
+		}
+
+		return c.Next()
+	}
+}
+
+// Bucket is a named token bucket and the limit it is taken from with
+type Bucket struct {
+	Name  string
+	Limit Limit
+}
+
+// BucketsFor returns the buckets a caller takes from: the client IP's, and
+// the key's own bucket and budget when authenticated. Sharing a key across
+// many addresses does not lift the per-IP limit, but the IP bucket of a key
+// with a larger budget grows with it so the key can spend its budget.
+func BucketsFor(key *database.APIKey, ip string, def Limit) []Bucket {
+	if key == nil {
+		return []Bucket{{Name: "ip:" + ip, Limit: def}}
+	}
+
+	limit := def
+	if key.RateLimit != nil && *key.RateLimit > 0 {
+		limit.Requests = *key.RateLimit
+	}
+	ipLimit := def
+	ipLimit.Requests = max(def.Requests, limit.Requests)
+	return []Bucket{
+		{Name: "key:" + strconv.Itoa(key.ID), Limit: limit},
+		{Name: "ip:" + ip, Limit: ipLimit},
+	}
+}
+
+// Take takes a token from every bucket and returns the most restrictive
+// decision with the limit it came from: the first bucket that refused, or
+// the one with the fewest tokens left
+func Take(ctx context.Context, limiter Limiter, buckets []Bucket) (Decision, Limit, error) {
+	var decision Decision
+	var limit Limit
+	for i, b := range buckets {
+		d, err := limiter.Take(ctx, b.Name, b.Limit)
+		if err != nil {
+			return Decision{}, Limit{}, err
+		}
+		if !d.Allowed {
+			return d, b.Limit, nil
+		}
+		if i == 0 || d.Remaining < decision.Remaining {
+			decision, limit = d, b.Limit
+		}
+	}
+	return decision, limit, nil
+}
+
+// MemoryLimiter keeps buckets in process memory
+type MemoryLimiter struct {
+	mu      sync.Mutex
+	buckets map[string]*bucket
+	sweepAt time.Time
+}
+
+type bucket struct {
+	tokens  float64
+	updated time.Time
+	idleTTL time.Duration
+}
+
+// NewMemoryLimiter creates an in-memory limiter
+func NewMemoryLimiter() *MemoryLimiter {
+	return &MemoryLimiter{buckets: make(map[string]*bucket)}
+}
+
+// Take implements Limiter
+func (m *MemoryLimiter) Take(_ context.Context, name string, limit Limit) (Decision, error) {
+	now := time.Now()
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sweep(now)
+
+	b, ok := m.buckets[name]
+	if !ok {
+		b = &bucket{tokens: float64(limit.Requests), updated: now}
+		m.buckets[name] = b
+	}
+	b.idleTTL = limit.Window
+
+	b.tokens = math.Min(float64(limit.Requests), b.tokens+now.Sub(b.updated).Seconds()*limit.rate())
+	b.updated = now
+
+	if b.tokens < 1 {
+		wait := (1 - b.tokens) / limit.rate()
+		return Decision{Allowed: false, RetryAfter: time.Duration(wait * float64(time.Second))}, nil
+	}
+
+	b.tokens--
+	return Decision{Allowed: true, Remaining: int(b.tokens)}, nil
+}
+
+// sweep drops buckets that have been idle long enough to be full again
+func (m *MemoryLimiter) sweep(now time.Time) {
+	if now.Before(m.sweepAt) {
+		return
+	}
+	for name, b := range m.buckets {
+		if now.Sub(b.updated) > b.idleTTL {
+			delete(m.buckets, name)
+		}
+	}
+	m.sweepAt = now.Add(time.Minute)
+}
+
+// redisLimiter keeps buckets in Redis and falls back to memory if Redis is unreachable
+type redisLimiter struct {
+	client   *redis.Client
+	fallback *MemoryLimiter
+}
+
+// tokenBucketScript refills and takes from a bucket atomically.
+// Returns {allowed, remaining, retry_after_ms}.
+var tokenBucketScript = redis.NewScript(`
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'ts')
+local tokens = tonumber(state[1]) or burst
+local ts = tonumber(state[2]) or now
+
+tokens = math.min(burst, tokens + (now - ts) / 1000 * rate)
+
+local allowed = 0
+local retry = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+else
+	retry = math.ceil((1 - tokens) / rate * 1000)
+end
+
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'ts', now)
+redis.call('PEXPIRE', KEYS[1], math.ceil(burst / rate * 1000))
+
+return {allowed, math.floor(tokens), retry}
+`)
+
+// Take implements Limiter
+func (r *redisLimiter) Take(ctx context.Context, name string, limit Limit) (Decision, error) {
+	res, err := tokenBucketScript.Run(
+		ctx, r.client, []string{"ratelimit:" + name},
+		limit.rate(), limit.Requests, time.Now().UnixMilli(),
+	).Int64Slice()
+	if err != nil {
+		log.Printf("Warning: redis rate limiter unavailable, using memory: %v\n", err)
+		return r.fallback.Take(ctx, name, limit)
+	}
+	if len(res) != 3 {
+		return Decision{}, fmt.Errorf("unexpected rate limiter reply: %v", res)
+	}
+
+	return Decision{
+		Allowed:    res[0] == 1,
+		Remaining:  int(res[1]),
+		RetryAfter: time.Duration(res[2]) * time.Millisecond,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package blockchain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// factoryABI covers the PollFactory event and the registry views the
+// listener relies on
+const factoryABI = `[
+	{"type":"event","name":"PollCreated","anonymous":false,"inputs":[
+		{"name":"pollId","type":"uint256","indexed":true},
+		{"name":"pollAddress","type":"address","indexed":true},
+		{"name":"creator","type":"address","indexed":true},
+		{"name":"question","type":"string","indexed":false},
+		{"name":"duration","type":"uint256","indexed":false}]},
+	{"type":"function","name":"pollIds","stateMutability":"view",
+		"inputs":[{"name":"","type":"address"}],
+		"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"polls","stateMutability":"view",
+		"inputs":[{"name":"","type":"uint256"}],
+		"outputs":[{"name":"","type":"address"}]}
+]`
+
+// pollABI covers the Poll events, the views read when a poll is created
+// or served before it is indexed, and the counters used to cross-check
+// historical queries
+const pollABI = `[
+	{"type":"event","name":"VoteCommitted","anonymous":false,"inputs":[
+		{"name":"voter","type":"address","indexed":true},
+		{"name":"commitment","type":"bytes32","indexed":false},
+		{"name":"timestamp","type":"uint256","indexed":false}]},
+	{"type":"event","name":"VoteRevealed","anonymous":false,"inputs":[
+		{"name":"voter","type":"address","indexed":true},
+		{"name":"choice","type":"uint256","indexed":false},
+		{"name":"timestamp","type":"uint256","indexed":false}]},
+	{"type":"event","name":"PollClosed","anonymous":false,"inputs":[
+		{"name":"timestamp","type":"uint256","indexed":false}]},
+	{"type":"event","name":"ResultsTallied","anonymous":false,"inputs":[
+		{"name":"results","type":"uint256[]","indexed":false},
+		{"name":"timestamp","type":"uint256","indexed":false}]},
+	{"type":"function","name":"question","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"options","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"string[]"}]},
+	{"type":"function","name":"duration","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"voterMerkleRoot","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"bytes32"}]},
+	{"type":"function","name":"createdAt","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"endTime","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"state","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"totalCommitted","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"totalRevealed","stateMutability":"view","inputs":[],
+		"outputs":[{"name":"","type":"uint256"}]}
+]`
+
+var (
+	factoryContractABI = mustParseABI(factoryABI)
+	pollContractABI    = mustParseABI(pollABI)
+
+	// eventNames maps each event signature hash (topic 0) to its event name
+	eventNames = map[common.Hash]string{}
+	// eventDefs maps each event signature hash to its ABI definition
+	eventDefs = map[common.Hash]abi.Event{}
+	// eventTopics lists every signature hash the listener filters for
+	eventTopics []common.Hash
+)
+
+func init() {
+	for _, parsed := range []abi.ABI{factoryContractABI, pollContractABI} {
+		for name, event := range parsed.Events {
+			eventNames[event.ID] = name
+			eventDefs[event.ID] = event
+			eventTopics = append(eventTopics, event.ID)
+		}
+	}
+}
+
+func mustParseABI(definition string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(definition))
+	if err != nil {
+		panic(fmt.Sprintf("invalid contract ABI: %v", err))
+	}
+	return parsed
+}
@@ -0,0 +1,151 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ChainPoll is a poll read straight from its contract, for polls the
+// indexer has not stored yet
+type ChainPoll struct {
+	Poll     *database.Poll // Block number and transaction hash come from the factory's PollCreated log
+	Counters *PollCounters
+}
+
+// ReadPoll reads a poll from its contract at the latest block. It returns
+// nil when factory did not deploy address, or deployed it before
+// fromBlock, the factory's start block.
+func (c *Client) ReadPoll(ctx context.Context, factory, address common.Address, fromBlock uint64) (*ChainPoll, error) {
+	opts := &bind.CallOpts{Context: ctx}
+
+	// pollIds is 0 both for the factory's first poll and for an address it
+	// never deployed, so the ID is checked against the registry
+	var ids []interface{}
+	factoryContract := bind.NewBoundContract(factory, factoryContractABI, c, nil, nil)
+	if err := factoryContract.Call(opts, &ids, "pollIds", address); err != nil {
+		return nil, fmt.Errorf("failed to look up poll %s: %w", address.Hex(), err)
+	}
+	id, _ := ids[0].(*big.Int)
+	if id == nil {
+		return nil, fmt.Errorf("failed to look up poll %s: unexpected pollIds result", address.Hex())
+	}
+	var registry []interface{}
+	if err := factoryContract.Call(opts, &registry, "polls", id); err != nil {
+		return nil, fmt.Errorf("failed to look up poll %s: %w", address.Hex(), err)
+	}
+	if registered, _ := registry[0].(common.Address); registered != address {
+		return nil, nil
+	}
+
+	contract := bind.NewBoundContract(address, pollContractABI, c, nil, nil)
+	views := map[string]interface{}{}
+	for _, method := range []string{"question", "options", "duration", "voterMerkleRoot", "createdAt", "endTime"} {
+		var out []interface{}
+		if err := contract.Call(opts, &out, method); err != nil {
+			return nil, fmt.Errorf("%s call failed: %w", method, err)
+		}
+		views[method] = out[0]
+	}
+
+	// The creation log gives the block the indexer must reach for this poll
+	// and the creator: the poll's own creator view holds the factory, which
+	// deployed it. createdAt pins the log to a single block, so no range
+	// of blocks is ever scanned.
+	block, err := c.blockAtTime(ctx, views["createdAt"].(*big.Int).Uint64(), fromBlock)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find creation block of %s: %w", address.Hex(), err)
+	}
+	if block == nil {
+		return nil, nil
+	}
+	logs, err := c.FilterLogs(ctx, ethereum.FilterQuery{
+		FromBlock: block,
+		ToBlock:   block,
+		Addresses: []common.Address{factory},
+		Topics: [][]common.Hash{
+			{factoryContractABI.Events["PollCreated"].ID},
+			nil,
+			{common.BytesToHash(address.Bytes())},
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to find PollCreated log for %s: %w", address.Hex(), err)
+	}
+	if len(logs) == 0 {
+		return nil, nil
+	}
+	created := logs[0]
+	creator, err := topicAddress(created, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	counters, err := c.pollCounters(ctx, address, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	deployer := database.Address(factory)
+	poll := &database.Poll{
+		ChainID:         c.ChainID.Int64(),
+		Factory:         &deployer,
+		ContractAddress: database.Address(address),
+		Question:        views["question"].(string),
+		Options:         views["options"].([]string),
+		Duration:        int(views["duration"].(*big.Int).Int64()),
+		VoterMerkleRoot: common.Hash(views["voterMerkleRoot"].([32]byte)).Hex(),
+		CreatedAt:       unixTime(views["createdAt"].(*big.Int)),
+		ClosesAt:        unixTime(views["endTime"].(*big.Int)),
+		State:           counters.State,
+		Creator:         database.Address(creator),
+		BlockNumber:     int64(created.BlockNumber),
+		TransactionHash: created.TxHash.Hex(),
+		Tags:            []string{},
+	}
+
+	return &ChainPoll{Poll: poll, Counters: counters}, nil
+}
+
+// blockAtTime returns the block mined at timestamp, searching from
+// fromBlock to the latest block. Block timestamps strictly increase, so a
+// binary search needs about log2(blocks) header reads. It returns nil when
+// no block in the range was mined at timestamp.
+func (c *Client) blockAtTime(ctx context.Context, timestamp, fromBlock uint64) (*big.Int, error) {
+	latest, err := c.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	lo, hi := fromBlock, latest.Number.Uint64()
+	if lo > hi || latest.Time < timestamp {
+		return nil, nil
+	}
+
+	// Find the first block mined at or after timestamp
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(mid))
+		if err != nil {
+			return nil, err
+		}
+		if header.Time < timestamp {
+			lo = mid + 1
+		} else {
+			hi = mid
+		}
+	}
+
+	header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(lo))
+	if err != nil {
+		return nil, err
+	}
+	if header.Time != timestamp {
+		return nil, nil
+	}
+	return header.Number, nil
+}
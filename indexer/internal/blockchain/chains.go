@@ -0,0 +1,191 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// AnvilChainID is the default chain when none is configured
+const AnvilChainID = 31337
+
+// ChainConfig is one (chain, factory) pair the indexer watches
+type ChainConfig struct {
+	ChainID    int64          `json:"chain_id"` // 0 takes the chain ID reported by the node; only allowed for a single entry
+	RPCURL     string         `json:"rpc_url"`
+	Factory    common.Address `json:"factory"`
+	StartBlock uint64         `json:"start_block"` // First block to index when nothing is indexed yet
+}
+
+// ChainsFromEnv reads the watched pairs from CHAINS, a JSON array of
+// ChainConfig. Without CHAINS the single pair given by RPC_URL,
+// POLL_FACTORY_ADDRESS and START_BLOCK is watched on whatever chain that
+// node serves; its factory is the zero address if POLL_FACTORY_ADDRESS is
+// not set.
+func ChainsFromEnv() ([]ChainConfig, error) {
+	raw := os.Getenv("CHAINS")
+	if raw == "" {
+		rpcURL := os.Getenv("RPC_URL")
+		if rpcURL == "" {
+			rpcURL = "http://localhost:8545" // Default to local Anvil
+		}
+		var factory common.Address
+		if v := os.Getenv("POLL_FACTORY_ADDRESS"); common.IsHexAddress(v) {
+			factory = common.HexToAddress(v)
+		}
+		var startBlock uint64
+		if v := os.Getenv("START_BLOCK"); v != "" {
+			var err error
+			if startBlock, err = strconv.ParseUint(v, 10, 64); err != nil {
+				return nil, fmt.Errorf("invalid START_BLOCK %q", v)
+			}
+		}
+		return []ChainConfig{{RPCURL: rpcURL, Factory: factory, StartBlock: startBlock}}, nil
+	}
+
+	var configs []ChainConfig
+	if err := json.Unmarshal([]byte(raw), &configs); err != nil {
+		return nil, fmt.Errorf("invalid CHAINS: %w", err)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("CHAINS lists no chains")
+	}
+
+	seen := map[string]bool{}
+	for i, cfg := range configs {
+		if cfg.RPCURL == "" {
+			return nil, fmt.Errorf("CHAINS[%d]: rpc_url is required", i)
+		}
+		if cfg.Factory == (common.Address{}) {
+			return nil, fmt.Errorf("CHAINS[%d]: factory is required", i)
+		}
+		// Duplicates can only be caught on IDs known before any node is
+		// dialled, so several entries must each name their chain
+		if cfg.ChainID == 0 && len(configs) > 1 {
+			return nil, fmt.Errorf("CHAINS[%d]: chain_id is required when several chains are listed", i)
+		}
+		key := fmt.Sprintf("%d:%s", cfg.ChainID, cfg.Factory.Hex())
+		if seen[key] {
+			return nil, fmt.Errorf("CHAINS[%d]: factory %s is listed twice for chain %d", i, cfg.Factory.Hex(), cfg.ChainID)
+		}
+		seen[key] = true
+	}
+
+	return configs, nil
+}
+
+// DefaultChainID returns the chain that unscoped requests are served from:
+// DEFAULT_CHAIN_ID if set, otherwise the first configured chain whose ID
+// is known, otherwise Anvil
+func DefaultChainID(configs []ChainConfig) int64 {
+	if v := os.Getenv("DEFAULT_CHAIN_ID"); v != "" {
+		if id, err := strconv.ParseInt(v, 10, 64); err == nil && id > 0 {
+			return id
+		}
+		log.Printf("Warning: ignoring invalid DEFAULT_CHAIN_ID %q\n", v)
+	}
+
+	for _, cfg := range configs {
+		if cfg.ChainID != 0 {
+			return cfg.ChainID
+		}
+	}
+	return AnvilChainID
+}
+
+// Chain is a chain the API serves
+type Chain struct {
+	ID        int64
+	Client    *Client // Nil when its node is unreachable; disables chain reads
+	Factories []common.Address
+
+	startBlocks map[common.Address]uint64
+}
+
+// Chains are the chains the API serves, by ID. Requests that do not name
+// a chain are served from the default one.
+type Chains struct {
+	Default int64
+	byID    map[int64]*Chain
+}
+
+// NewChains connects to the node of every configured chain. A node that
+// cannot be reached only disables reads from that chain; the chain is
+// still served from the index if its ID is configured.
+func NewChains(ctx context.Context, configs []ChainConfig) *Chains {
+	chains := &Chains{byID: map[int64]*Chain{}}
+
+	for i := range configs {
+		cfg := &configs[i]
+		if chain := chains.byID[cfg.ChainID]; chain != nil && cfg.ChainID != 0 {
+			chain.addFactory(cfg)
+			continue
+		}
+
+		client, err := NewClientForChain(ctx, cfg)
+		if err != nil {
+			log.Printf("Warning: Ethereum node for chain %d not available, chain reads are disabled: %v", cfg.ChainID, err)
+			client = nil
+		} else {
+			log.Printf("Connected to Ethereum node (Chain ID: %d)\n", cfg.ChainID)
+		}
+		if cfg.ChainID == 0 {
+			continue // The node was needed to learn which chain this is
+		}
+
+		chain := chains.byID[cfg.ChainID]
+		if chain == nil {
+			chain = &Chain{ID: cfg.ChainID}
+			chains.byID[cfg.ChainID] = chain
+		}
+		if chain.Client == nil {
+			chain.Client = client
+		}
+		chain.addFactory(cfg)
+	}
+
+	chains.Default = DefaultChainID(configs)
+	if chains.byID[chains.Default] == nil {
+		chains.byID[chains.Default] = &Chain{ID: chains.Default}
+	}
+
+	return chains
+}
+
+func (c *Chain) addFactory(cfg *ChainConfig) {
+	if cfg.Factory == (common.Address{}) {
+		return
+	}
+	if c.startBlocks == nil {
+		c.startBlocks = map[common.Address]uint64{}
+	}
+	c.Factories = append(c.Factories, cfg.Factory)
+	c.startBlocks[cfg.Factory] = cfg.StartBlock
+}
+
+// StartBlock returns the configured start block of one of the chain's
+// factories, the first block searched for its logs
+func (c *Chain) StartBlock(factory common.Address) uint64 {
+	return c.startBlocks[factory]
+}
+
+// Get returns the chain with the given ID, or nil if it is not served
+func (c *Chains) Get(id int64) *Chain {
+	return c.byID[id]
+}
+
+// IDs returns the IDs of the served chains in ascending order
+func (c *Chains) IDs() []int64 {
+	ids := make([]int64, 0, len(c.byID))
+	for id := range c.byID {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
@@ -15,13 +15,18 @@ type Client struct {
 	ChainID *big.Int
 }
 
-// NewClient creates a new Ethereum client
+// NewClient creates a new Ethereum client for the node at RPC_URL
 func NewClient(ctx context.Context) (*Client, error) {
 	rpcURL := os.Getenv("RPC_URL")
 	if rpcURL == "" {
 		rpcURL = "http://localhost:8545" // Default to local Anvil
 	}
 
+	return NewClientForURL(ctx, rpcURL)
+}
+
+// NewClientForURL creates a new Ethereum client for the node at rpcURL
+func NewClientForURL(ctx context.Context, rpcURL string) (*Client, error) {
 	client, err := ethclient.Dial(rpcURL)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to Ethereum node: %w", err)
@@ -37,3 +42,21 @@ func NewClient(ctx context.Context) (*Client, error) {
 		ChainID: chainID,
 	}, nil
 }
+
+// NewClientForChain connects to a configured chain's node and checks that
+// it serves that chain. A config without a chain ID takes the node's.
+func NewClientForChain(ctx context.Context, cfg *ChainConfig) (*Client, error) {
+	client, err := NewClientForURL(ctx, cfg.RPCURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if cfg.ChainID == 0 {
+		cfg.ChainID = client.ChainID.Int64()
+	} else if client.ChainID.Int64() != cfg.ChainID {
+		client.Close()
+		return nil, fmt.Errorf("node at %s serves chain %s, not %d", cfg.RPCURL, client.ChainID, cfg.ChainID)
+	}
+
+	return client, nil
+}
@@ -0,0 +1,117 @@
+package blockchain
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// readControl refreshes the paused flag from the chain's indexer_control
+// row and reports whether it changed
+func (l *Listener) readControl(ctx context.Context) bool {
+	control, err := l.db.GetIndexerControl(ctx)
+	if err != nil {
+		log.Printf("Warning: %v\n", err)
+		return false
+	}
+
+	changed := control.Paused != l.paused
+	l.paused = control.Paused
+	return changed
+}
+
+// applyControl follows pause/resume requests and runs queued commands.
+// On resume the listener catches up on the blocks it skipped.
+func (l *Listener) applyControl(ctx context.Context) {
+	if l.readControl(ctx) {
+		if l.paused {
+			log.Printf("Indexing paused for listener %s\n", l.ID())
+		} else {
+			log.Printf("Indexing resumed for listener %s\n", l.ID())
+			if l.lastBlock > 0 {
+				l.startBlock = l.lastBlock + 1
+			}
+			if err := l.processHistoricalBlocks(ctx); err != nil {
+				log.Printf("Warning: failed to catch up after resume: %v\n", err)
+			}
+		}
+		l.updateStatus(ctx)
+	}
+
+	for ctx.Err() == nil {
+		cmd, err := l.db.ClaimIndexerCommand(ctx, database.Address(l.pollFactory))
+		if err != nil {
+			log.Printf("Warning: %v\n", err)
+			return
+		}
+		if cmd == nil {
+			return
+		}
+
+		log.Printf("Listener %s running indexer command %d: %s of blocks %d-%d\n", l.ID(), cmd.ID, cmd.Kind, cmd.FromBlock, cmd.ToBlock)
+		cmdErr := l.runCommand(ctx, cmd)
+		if cmdErr != nil {
+			log.Printf("Indexer command %d failed: %v\n", cmd.ID, cmdErr)
+		}
+		if err := l.db.FinishIndexerCommand(ctx, cmd.ID, cmdErr); err != nil {
+			log.Printf("Warning: %v\n", err)
+		}
+	}
+}
+
+// runCommand executes a reindex command. Handlers run again for every
+// event in the range; they are idempotent, so stored rows are refreshed
+// rather than duplicated and events that failed before get another try.
+func (l *Listener) runCommand(ctx context.Context, cmd *database.IndexerCommand) error {
+	var addresses []common.Address
+	var poll common.Address
+
+	switch cmd.Kind {
+	case database.CommandReindexRange:
+	case database.CommandReindexPoll:
+		if cmd.PollAddress == nil {
+			return fmt.Errorf("poll reindex without a poll address")
+		}
+		poll = common.Address(*cmd.PollAddress)
+		addresses = []common.Address{l.pollFactory, poll}
+	default:
+		return fmt.Errorf("unknown command kind %q", cmd.Kind)
+	}
+
+	var failed int
+	for fromBlock := uint64(cmd.FromBlock); fromBlock <= uint64(cmd.ToBlock); fromBlock += batchSize {
+		toBlock := fromBlock + batchSize - 1
+		if toBlock > uint64(cmd.ToBlock) {
+			toBlock = uint64(cmd.ToBlock)
+		}
+
+		logs, err := l.filterLogs(ctx, fromBlock, toBlock, addresses)
+		if err != nil {
+			return err
+		}
+
+		for _, vLog := range logs {
+			// The factory announces every poll; only its own creation
+			// log matters when reindexing one poll
+			if addresses != nil && vLog.Address == l.pollFactory {
+				created, err := topicAddress(vLog, 2)
+				if err != nil || created != poll {
+					continue
+				}
+			}
+
+			if err := l.processLog(ctx, vLog, true); err != nil {
+				log.Printf("Error reprocessing log: %v\n", err)
+				failed++
+			}
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d events failed; see failed events", failed)
+	}
+	return nil
+}
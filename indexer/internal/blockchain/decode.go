@@ -0,0 +1,62 @@
+package blockchain
+
+import (
+	"fmt"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// DecodeEvent returns the arguments of a stored event by name, indexed and
+// not. Addresses become database.Address and fixed-size byte values hex
+// strings, so the result serializes the way the rest of the API does.
+func DecodeEvent(event *database.Event) (map[string]interface{}, error) {
+	vLog, err := storedLog(event)
+	if err != nil {
+		return nil, err
+	}
+	if len(vLog.Topics) == 0 {
+		return nil, fmt.Errorf("stored %s event %d has no topics", event.EventName, event.ID)
+	}
+
+	def, ok := eventDefs[vLog.Topics[0]]
+	if !ok {
+		return nil, fmt.Errorf("unknown event signature %s", vLog.Topics[0].Hex())
+	}
+
+	args := map[string]interface{}{}
+	if err := def.Inputs.UnpackIntoMap(args, vLog.Data); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", def.Name, err)
+	}
+
+	var indexed abi.Arguments
+	for _, input := range def.Inputs {
+		if input.Indexed {
+			indexed = append(indexed, input)
+		}
+	}
+	if err := abi.ParseTopicsIntoMap(args, indexed, vLog.Topics[1:]); err != nil {
+		return nil, fmt.Errorf("failed to decode %s topics: %w", def.Name, err)
+	}
+
+	for name, value := range args {
+		args[name] = jsonValue(value)
+	}
+	return args, nil
+}
+
+// jsonValue converts a decoded ABI value to its API representation
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case common.Address:
+		return database.Address(v)
+	case [32]byte:
+		return common.Hash(v).Hex()
+	case []byte:
+		return hexutil.Bytes(v)
+	default:
+		return v
+	}
+}
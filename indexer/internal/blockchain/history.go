@@ -0,0 +1,223 @@
+package blockchain
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// pollStates maps the Poll contract's PollState enum to indexed state names
+var pollStates = []string{"active", "closed", "tallied"}
+
+// Snapshot is a poll as the indexer would have stored it at a past block,
+// rebuilt from the raw events
+type Snapshot struct {
+	Poll      *database.Poll   // Copy of the poll with its state at the block
+	Votes     []*database.Vote // In commit order
+	Committed int
+	Revealed  int
+}
+
+// Rebuild replays a poll's stored events up to and including block. Events
+// must belong to the poll and be in chain order. The poll must have been
+// created at or before block.
+func Rebuild(poll *database.Poll, events []*database.Event, block uint64) (*Snapshot, error) {
+	snapshot := &Snapshot{
+		Poll:  new(database.Poll),
+		Votes: []*database.Vote{},
+	}
+	*snapshot.Poll = *poll
+	snapshot.Poll.State = "active"
+
+	votes := map[common.Address]*database.Vote{}
+
+	for _, event := range events {
+		if uint64(event.BlockNumber) > block {
+			break
+		}
+
+		vLog, err := storedLog(event)
+		if err != nil {
+			return nil, err
+		}
+
+		switch event.EventName {
+		case "VoteCommitted":
+			var ev struct {
+				Commitment [32]byte
+				Timestamp  *big.Int
+			}
+			if err := unpackEvent(pollContractABI, event.EventName, vLog, &ev); err != nil {
+				return nil, err
+			}
+			voter, err := topicAddress(vLog, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			// The contract accepts one commitment per voter
+			if _, seen := votes[voter]; seen {
+				continue
+			}
+
+			vote := &database.Vote{
+				PollAddress:     poll.ContractAddress,
+				Voter:           database.Address(voter),
+				Commitment:      common.Hash(ev.Commitment).Hex(),
+				CommittedAt:     unixTime(ev.Timestamp),
+				BlockNumber:     event.BlockNumber,
+				TransactionHash: event.TransactionHash,
+			}
+			votes[voter] = vote
+			snapshot.Votes = append(snapshot.Votes, vote)
+			snapshot.Committed++
+
+		case "VoteRevealed":
+			var ev struct {
+				Choice    *big.Int
+				Timestamp *big.Int
+			}
+			if err := unpackEvent(pollContractABI, event.EventName, vLog, &ev); err != nil {
+				return nil, err
+			}
+			voter, err := topicAddress(vLog, 1)
+			if err != nil {
+				return nil, err
+			}
+
+			vote, ok := votes[voter]
+			if !ok {
+				return nil, fmt.Errorf("reveal by %s at block %d has no indexed commitment", voter.Hex(), event.BlockNumber)
+			}
+			if !vote.Revealed {
+				snapshot.Revealed++
+			}
+			choice := int(ev.Choice.Int64())
+			revealedAt := unixTime(ev.Timestamp)
+			revealedBlock := event.BlockNumber
+			vote.Choice = &choice
+			vote.Revealed = true
+			vote.RevealedAt = &revealedAt
+			vote.RevealedBlock = &revealedBlock
+
+		case "PollClosed":
+			if snapshot.Poll.State == "active" {
+				snapshot.Poll.State = "closed"
+			}
+
+		case "ResultsTallied":
+			snapshot.Poll.State = "tallied"
+		}
+	}
+
+	return snapshot, nil
+}
+
+// storedLog reconstructs the log an event row was indexed from
+func storedLog(event *database.Event) (types.Log, error) {
+	var raw struct {
+		Topics []common.Hash `json:"topics"`
+		Data   string        `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(event.EventData), &raw); err != nil {
+		return types.Log{}, fmt.Errorf("failed to decode stored %s event %d: %w", event.EventName, event.ID, err)
+	}
+
+	return types.Log{
+		Address:     common.Address(event.ContractAddress),
+		Topics:      raw.Topics,
+		Data:        common.FromHex(raw.Data),
+		BlockNumber: uint64(event.BlockNumber),
+		TxHash:      common.HexToHash(event.TransactionHash),
+		Index:       uint(event.LogIndex),
+	}, nil
+}
+
+// BlockRef identifies a block
+type BlockRef struct {
+	Number    uint64 `json:"number"`
+	Hash      string `json:"hash"`
+	Timestamp int64  `json:"timestamp"` // Unix seconds
+}
+
+// BlockAt returns the number, hash and timestamp of a block
+func (c *Client) BlockAt(ctx context.Context, block uint64) (*BlockRef, error) {
+	header, err := c.HeaderByNumber(ctx, new(big.Int).SetUint64(block))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get block %d: %w", block, err)
+	}
+
+	return &BlockRef{
+		Number:    block,
+		Hash:      header.Hash().Hex(),
+		Timestamp: int64(header.Time),
+	}, nil
+}
+
+// PollCounters are the on-chain state and vote counters of a poll
+type PollCounters struct {
+	State          string `json:"state"`
+	TotalCommitted int    `json:"total_committed"`
+	TotalRevealed  int    `json:"total_revealed"`
+}
+
+// PollCountersAt reads a poll's state and counters with eth_call pinned to block
+func (c *Client) PollCountersAt(ctx context.Context, poll common.Address, block uint64) (*PollCounters, error) {
+	counters, err := c.pollCounters(ctx, poll, new(big.Int).SetUint64(block))
+	if err != nil {
+		return nil, fmt.Errorf("call at block %d: %w", block, err)
+	}
+	return counters, nil
+}
+
+// pollCounters reads a poll's state and counters at block, or at the
+// latest block when block is nil
+func (c *Client) pollCounters(ctx context.Context, poll common.Address, block *big.Int) (*PollCounters, error) {
+	contract := bind.NewBoundContract(poll, pollContractABI, c, nil, nil)
+	opts := &bind.CallOpts{Context: ctx, BlockNumber: block}
+
+	counters := &PollCounters{}
+	for _, method := range []string{"state", "totalCommitted", "totalRevealed"} {
+		var out []interface{}
+		if err := contract.Call(opts, &out, method); err != nil {
+			return nil, fmt.Errorf("%s call failed: %w", method, err)
+		}
+
+		switch v := out[0].(type) {
+		case uint8:
+			if int(v) >= len(pollStates) {
+				return nil, fmt.Errorf("unknown poll state %d", v)
+			}
+			counters.State = pollStates[v]
+		case *big.Int:
+			if method == "totalCommitted" {
+				counters.TotalCommitted = int(v.Int64())
+			} else {
+				counters.TotalRevealed = int(v.Int64())
+			}
+		}
+	}
+
+	return counters, nil
+}
+
+// Mismatches lists the fields where a snapshot disagrees with the chain
+func (s *Snapshot) Mismatches(chain *PollCounters) []string {
+	var fields []string
+	if s.Poll.State != chain.State {
+		fields = append(fields, "state")
+	}
+	if s.Committed != chain.TotalCommitted {
+		fields = append(fields, "total_committed")
+	}
+	if s.Revealed != chain.TotalRevealed {
+		fields = append(fields, "total_revealed")
+	}
+	return fields
+}
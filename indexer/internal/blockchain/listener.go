@@ -6,48 +6,105 @@ import (
 	"fmt"
 	"log"
 	"math/big"
+	"time"
 
 	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/webhook"
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
 	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
-// Listener listens for blockchain events and processes them
+// heartbeatInterval is how often the listener refreshes the chain head and
+// writes its status row, even when no new blocks arrive
+const heartbeatInterval = 15 * time.Second
+
+// controlInterval is how often the listener reads the operator control
+// table for pause/resume and queued reindex commands
+const controlInterval = 2 * time.Second
+
+// statsInterval is the minimum time between refreshes of the platform
+// statistics views; they are only refreshed after new events are handled
+const statsInterval = 30 * time.Second
+
+// batchSize bounds the block range of a single eth_getLogs call
+const batchSize = uint64(1000)
+
+// Listener listens for the events of one factory on one chain and
+// processes them. Everything it stores is scoped to its chain.
 type Listener struct {
-	client   *Client
-	db       *database.DB
+	client      *Client
+	db          *database.DB
+	chainID     int64
 	pollFactory common.Address
-	startBlock uint64
+	startBlock  uint64
+	metrics     *instrumentation.Indexer
+
+	// Progress reported in the indexer_status heartbeat
+	head       uint64
+	headTime   *time.Time // Timestamp of head; chain time for deadlines
+	lastBlock  uint64
+	subscribed bool
+
+	// paused mirrors indexer_control; new blocks are skipped while set
+	paused bool
+
+	// statsStale is set when handled events have changed the data behind
+	// the platform statistics views
+	statsStale bool
+
+	// polls caches whether a contract address was deployed by pollFactory
+	polls map[common.Address]bool
+
+	// Gas used by the most recent transaction, reused for its other logs
+	lastTx      common.Hash
+	lastGasUsed *int64
 }
 
-// NewListener creates a new event listener
-func NewListener(client *Client, db *database.DB, pollFactory string, startBlock uint64) *Listener {
+// NewListener creates a new event listener for a configured pair. The
+// client must be connected to the pair's chain.
+func NewListener(client *Client, db *database.DB, cfg ChainConfig, metrics *instrumentation.Indexer) *Listener {
 	return &Listener{
 		client:      client,
 		db:          db,
-		pollFactory: common.HexToAddress(pollFactory),
-		startBlock:  startBlock,
+		chainID:     cfg.ChainID,
+		pollFactory: cfg.Factory,
+		startBlock:  cfg.StartBlock,
+		metrics:     metrics,
+		polls:       make(map[common.Address]bool),
 	}
 }
 
 // Start begins listening for events
 func (l *Listener) Start(ctx context.Context) error {
-	log.Println("Starting blockchain event listener...")
+	log.Printf("Starting blockchain event listener %s...\n", l.ID())
+	ctx = database.WithChain(ctx, l.chainID)
 
-	// Get last processed block from database
-	lastBlock, err := l.db.GetLastProcessedBlock(ctx)
+	// Get the last block processed for this factory from the database
+	lastBlock, err := l.db.GetFactoryLastBlock(ctx, database.Address(l.pollFactory))
 	if err != nil {
 		return fmt.Errorf("failed to get last processed block: %w", err)
 	}
 
 	if lastBlock > 0 {
 		l.startBlock = uint64(lastBlock) + 1
-		log.Printf("Resuming from block %d\n", l.startBlock)
+		l.lastBlock = uint64(lastBlock)
+		log.Printf("Listener %s resuming from block %d\n", l.ID(), l.startBlock)
 	} else {
-		log.Printf("Starting from block %d\n", l.startBlock)
+		log.Printf("Listener %s starting from block %d\n", l.ID(), l.startBlock)
 	}
 
+	// Commands interrupted by a restart are run again
+	if n, err := l.db.RequeueRunningIndexerCommands(ctx, database.Address(l.pollFactory)); err != nil {
+		log.Printf("Warning: %v\n", err)
+	} else if n > 0 {
+		log.Printf("Requeued %d interrupted indexer commands\n", n)
+	}
+	l.readControl(ctx)
+
 	// Subscribe to new blocks
 	headers := make(chan *types.Header)
 	sub, err := l.client.SubscribeNewHead(ctx, headers)
@@ -55,43 +112,130 @@ func (l *Listener) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to subscribe to new blocks: %w", err)
 	}
 	defer sub.Unsubscribe()
+	l.subscribed = true
 
 	// Process historical blocks first
-	if err := l.processHistoricalBlocks(ctx); err != nil {
-		log.Printf("Warning: failed to process historical blocks: %v\n", err)
+	if !l.paused {
+		if err := l.processHistoricalBlocks(ctx); err != nil {
+			log.Printf("Warning: failed to process historical blocks: %v\n", err)
+		}
 	}
 
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	control := time.NewTicker(controlInterval)
+	defer control.Stop()
+	stats := time.NewTicker(statsInterval)
+	defer stats.Stop()
+
 	// Process new blocks as they arrive
 	for {
 		select {
 		case <-ctx.Done():
-			log.Println("Stopping event listener...")
+			log.Printf("Stopping event listener %s...\n", l.ID())
+			l.markUnsubscribed()
 			return ctx.Err()
 		case err := <-sub.Err():
+			l.markUnsubscribed()
 			return fmt.Errorf("subscription error: %w", err)
+		case <-heartbeat.C:
+			if err := l.refreshHead(ctx); err != nil {
+				log.Printf("Warning: %v\n", err)
+			}
+			l.updateStatus(ctx)
+		case <-control.C:
+			l.applyControl(ctx)
+		case <-stats.C:
+			l.refreshStats(ctx)
 		case header := <-headers:
+			if header.Number.Uint64() > l.head {
+				l.setHead(header)
+			}
+			if l.paused {
+				continue
+			}
 			if err := l.processBlock(ctx, header.Number.Uint64()); err != nil {
 				log.Printf("Error processing block %d: %v\n", header.Number.Uint64(), err)
+				continue
 			}
+			l.updateStatus(ctx)
 		}
 	}
 }
 
+// ID identifies this listener in the indexer_status table as
+// <chain ID>:<factory address>
+func (l *Listener) ID() string {
+	return fmt.Sprintf("%d:%s", l.chainID, database.Address(l.pollFactory).Lower())
+}
+
+// refreshHead reads the current chain head from the node
+func (l *Listener) refreshHead(ctx context.Context) error {
+	start := time.Now()
+	header, err := l.client.HeaderByNumber(ctx, nil)
+	l.metrics.ObserveRPC("eth_getBlockByNumber", start)
+	if err != nil {
+		return fmt.Errorf("failed to get current block: %w", err)
+	}
+
+	l.setHead(header)
+	return nil
+}
+
+// setHead records the chain head and its timestamp
+func (l *Listener) setHead(header *types.Header) {
+	headTime := time.Unix(int64(header.Time), 0).UTC()
+	l.head = header.Number.Uint64()
+	l.headTime = &headTime
+}
+
+// updateStatus publishes progress to metrics and the heartbeat row
+func (l *Listener) updateStatus(ctx context.Context) {
+	lag := uint64(0)
+	if l.head > l.lastBlock {
+		lag = l.head - l.lastBlock
+	}
+	l.metrics.HeadLag.Set(float64(lag))
+	l.metrics.LastBlock.Set(float64(l.lastBlock))
+
+	status := &database.IndexerStatus{
+		ListenerID: l.ID(),
+		LastBlock:  int64(l.lastBlock),
+		HeadBlock:  int64(l.head),
+		HeadTime:   l.headTime,
+		Subscribed: l.subscribed,
+		Paused:     l.paused,
+	}
+	if err := l.db.UpsertIndexerStatus(ctx, status); err != nil {
+		log.Printf("Warning: failed to write heartbeat: %v\n", err)
+	}
+}
+
+// markUnsubscribed records that the listener stopped receiving new heads.
+// It uses its own context because the listener's may already be cancelled.
+func (l *Listener) markUnsubscribed() {
+	l.subscribed = false
+
+	ctx, cancel := context.WithTimeout(database.WithChain(context.Background(), l.chainID), 5*time.Second)
+	defer cancel()
+	l.updateStatus(ctx)
+}
+
 // processHistoricalBlocks processes all blocks from startBlock to current block
 func (l *Listener) processHistoricalBlocks(ctx context.Context) error {
-	currentBlock, err := l.client.BlockNumber(ctx)
-	if err != nil {
-		return fmt.Errorf("failed to get current block number: %w", err)
+	if err := l.refreshHead(ctx); err != nil {
+		return err
 	}
+	currentBlock := l.head
 
 	if l.startBlock >= currentBlock {
+		l.updateStatus(ctx)
 		return nil // No historical blocks to process
 	}
 
 	log.Printf("Processing historical blocks from %d to %d\n", l.startBlock, currentBlock)
 
 	// Process in batches to avoid overwhelming the node
-	batchSize := uint64(1000)
 	for fromBlock := l.startBlock; fromBlock < currentBlock; fromBlock += batchSize {
 		toBlock := fromBlock + batchSize - 1
 		if toBlock > currentBlock {
@@ -102,41 +246,87 @@ func (l *Listener) processHistoricalBlocks(ctx context.Context) error {
 			return fmt.Errorf("failed to process block range %d-%d: %w", fromBlock, toBlock, err)
 		}
 
+		l.updateStatus(ctx)
 		log.Printf("Processed blocks %d-%d\n", fromBlock, toBlock)
 	}
 
 	return nil
 }
 
-// processBlockRange processes a range of blocks
+// processBlockRange processes a range of blocks.
+// Poll events are emitted by the poll contracts rather than the factory, so
+// logs are filtered by event signature and checked against the factory in processLog.
 func (l *Listener) processBlockRange(ctx context.Context, fromBlock, toBlock uint64) error {
-	query := ethereum.FilterQuery{
-		FromBlock: new(big.Int).SetUint64(fromBlock),
-		ToBlock:   new(big.Int).SetUint64(toBlock),
-		Addresses: []common.Address{l.pollFactory},
-	}
-
-	logs, err := l.client.FilterLogs(ctx, query)
+	logs, err := l.filterLogs(ctx, fromBlock, toBlock, nil)
 	if err != nil {
-		return fmt.Errorf("failed to filter logs: %w", err)
+		return err
 	}
 
 	for _, vLog := range logs {
-		if err := l.processLog(ctx, vLog); err != nil {
+		if err := l.processLog(ctx, vLog, false); err != nil {
 			log.Printf("Error processing log: %v\n", err)
 		}
 	}
 
+	l.metrics.BlocksProcessed.Add(float64(toBlock - fromBlock + 1))
+	if toBlock > l.lastBlock {
+		l.lastBlock = toBlock
+	}
+
 	return nil
 }
 
+// filterLogs fetches the indexed event logs in a block range, optionally
+// restricted to some contracts
+func (l *Listener) filterLogs(ctx context.Context, fromBlock, toBlock uint64, addresses []common.Address) ([]types.Log, error) {
+	query := ethereum.FilterQuery{
+		FromBlock: new(big.Int).SetUint64(fromBlock),
+		ToBlock:   new(big.Int).SetUint64(toBlock),
+		Addresses: addresses,
+		Topics:    [][]common.Hash{eventTopics},
+	}
+
+	start := time.Now()
+	logs, err := l.client.FilterLogs(ctx, query)
+	l.metrics.ObserveRPC("eth_getLogs", start)
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter logs: %w", err)
+	}
+
+	return logs, nil
+}
+
 // processBlock processes a single block
 func (l *Listener) processBlock(ctx context.Context, blockNumber uint64) error {
 	return l.processBlockRange(ctx, blockNumber, blockNumber)
 }
 
-// processLog processes a single log entry
-func (l *Listener) processLog(ctx context.Context, vLog types.Log) error {
+// processLog processes a single log entry. With reindex set, handlers run
+// again for events that were already stored.
+func (l *Listener) processLog(ctx context.Context, vLog types.Log, reindex bool) error {
+	if len(vLog.Topics) == 0 {
+		return nil
+	}
+	name, ok := eventNames[vLog.Topics[0]]
+	if !ok {
+		return nil
+	}
+
+	// Only index events from our factory and the polls it deployed
+	if name == "PollCreated" {
+		if vLog.Address != l.pollFactory {
+			return nil
+		}
+	} else {
+		ours, err := l.isFactoryPoll(ctx, vLog.Address)
+		if err != nil {
+			return err
+		}
+		if !ours {
+			return nil
+		}
+	}
+
 	// Store raw event
 	eventData, err := json.Marshal(map[string]interface{}{
 		"topics": vLog.Topics,
@@ -147,99 +337,352 @@ func (l *Listener) processLog(ctx context.Context, vLog types.Log) error {
 	}
 
 	event := &database.Event{
-		ContractAddress: vLog.Address.Hex(),
-		EventName:       "Unknown", // Will be determined by topic
+		ContractAddress: database.Address(vLog.Address),
+		EventName:       name,
 		EventData:       string(eventData),
 		BlockNumber:     int64(vLog.BlockNumber),
 		BlockHash:       vLog.BlockHash.Hex(),
 		TransactionHash: vLog.TxHash.Hex(),
 		LogIndex:        int(vLog.Index),
-	}
-
-	// Determine event type by topic
-	if len(vLog.Topics) > 0 {
-		event.EventName = l.getEventNameByTopic(vLog.Topics[0])
+		GasUsed:         l.gasUsed(ctx, vLog.TxHash),
 	}
 
 	if err := l.db.CreateEvent(ctx, event); err != nil {
+		l.metrics.HandlerErrors.WithLabelValues(event.EventName).Inc()
 		return fmt.Errorf("failed to save event: %w", err)
 	}
 
+	// Events already stored were handled on a previous pass
+	if event.ID == 0 && !reindex {
+		return nil
+	}
+	l.metrics.Events.WithLabelValues(event.EventName).Inc()
+	l.statsStale = true
+
 	// Process specific event types
 	switch event.EventName {
 	case "PollCreated":
-		return l.processPollCreatedEvent(ctx, vLog)
+		err = l.processPollCreatedEvent(ctx, vLog)
 	case "VoteCommitted":
-		return l.processVoteCommittedEvent(ctx, vLog)
+		err = l.processVoteCommittedEvent(ctx, vLog)
 	case "VoteRevealed":
-		return l.processVoteRevealedEvent(ctx, vLog)
+		err = l.processVoteRevealedEvent(ctx, vLog)
 	case "PollClosed":
-		return l.processPollClosedEvent(ctx, vLog)
+		err = l.processPollClosedEvent(ctx, vLog)
 	case "ResultsTallied":
-		return l.processResultsTalliedEvent(ctx, vLog)
+		err = l.processResultsTalliedEvent(ctx, vLog)
+	}
+
+	if err != nil {
+		l.metrics.HandlerErrors.WithLabelValues(event.EventName).Inc()
+		if recErr := l.db.RecordFailedEvent(ctx, event, err); recErr != nil {
+			log.Printf("Warning: %v\n", recErr)
+		}
+		return err
 	}
 
+	if reindex {
+		return l.db.ResolveFailedEvent(ctx, event.TransactionHash, event.LogIndex)
+	}
 	return nil
 }
 
-// getEventNameByTopic returns the event name for a given topic hash
-func (l *Listener) getEventNameByTopic(topic common.Hash) string {
-	// Event signatures (keccak256 hash of event signature)
-	// These will be replaced with actual ABI-generated signatures
-	switch topic.Hex() {
-	case "0x5c...": // PollCreated signature (placeholder)
-		return "PollCreated"
-	case "0x8a...": // VoteCommitted signature (placeholder)
-		return "VoteCommitted"
-	case "0x7d...": // VoteRevealed signature (placeholder)
-		return "VoteRevealed"
-	case "0x6b...": // PollClosed signature (placeholder)
-		return "PollClosed"
-	case "0x4e...": // ResultsTallied signature (placeholder)
-		return "ResultsTallied"
-	default:
-		return "Unknown"
+// gasUsed returns the gas used by a transaction, or nil if its receipt is unavailable
+func (l *Listener) gasUsed(ctx context.Context, tx common.Hash) *int64 {
+	if tx == l.lastTx {
+		return l.lastGasUsed
+	}
+
+	start := time.Now()
+	receipt, err := l.client.TransactionReceipt(ctx, tx)
+	l.metrics.ObserveRPC("eth_getTransactionReceipt", start)
+	if err != nil {
+		log.Printf("Warning: failed to get receipt for %s: %v\n", tx.Hex(), err)
+		return nil
 	}
+
+	gas := int64(receipt.GasUsed)
+	l.lastTx, l.lastGasUsed = tx, &gas
+	return &gas
 }
 
-// processPollCreatedEvent processes a PollCreated event
-func (l *Listener) processPollCreatedEvent(ctx context.Context, vLog types.Log) error {
-	// TODO: Parse event data using ABI
-	// For now, this is a placeholder
-	log.Printf("Processing PollCreated event at block %d\n", vLog.BlockNumber)
+// isFactoryPoll reports whether address is a poll deployed by the factory
+func (l *Listener) isFactoryPoll(ctx context.Context, address common.Address) (bool, error) {
+	if ours, ok := l.polls[address]; ok {
+		return ours, nil
+	}
+
+	out, err := l.call(ctx, l.pollFactory, factoryContractABI, "pollIds", address)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up poll %s: %w", address.Hex(), err)
+	}
+
+	id, _ := out[0].(*big.Int)
+	if id == nil {
+		return false, fmt.Errorf("failed to look up poll %s: unexpected pollIds result", address.Hex())
+	}
+
+	// pollIds is 0 both for the factory's first poll and for an address it
+	// never deployed, so the ID is checked against the registry
+	out, err = l.call(ctx, l.pollFactory, factoryContractABI, "polls", id)
+	if err != nil {
+		return false, fmt.Errorf("failed to look up poll %s: %w", address.Hex(), err)
+	}
+
+	registered, _ := out[0].(common.Address)
+	ours := registered == address
+	l.polls[address] = ours
+	return ours, nil
+}
+
+// call invokes a view method on a contract at the latest block
+func (l *Listener) call(ctx context.Context, address common.Address, parsed abi.ABI, method string, args ...interface{}) ([]interface{}, error) {
+	contract := bind.NewBoundContract(address, parsed, l.client, nil, nil)
+
+	var out []interface{}
+	start := time.Now()
+	err := contract.Call(&bind.CallOpts{Context: ctx}, &out, method, args...)
+	l.metrics.ObserveRPC("eth_call", start)
+	if err != nil {
+		return nil, fmt.Errorf("%s call failed: %w", method, err)
+	}
+
+	return out, nil
+}
+
+// unpackEvent decodes the non-indexed fields of a log into out
+func unpackEvent(parsed abi.ABI, name string, vLog types.Log, out interface{}) error {
+	if err := parsed.UnpackIntoInterface(out, name, vLog.Data); err != nil {
+		return fmt.Errorf("failed to decode %s: %w", name, err)
+	}
 	return nil
 }
 
+// topicAddress returns the address stored in an indexed topic
+func topicAddress(vLog types.Log, index int) (common.Address, error) {
+	if len(vLog.Topics) <= index {
+		return common.Address{}, fmt.Errorf("log is missing topic %d", index)
+	}
+	return common.BytesToAddress(vLog.Topics[index].Bytes()), nil
+}
+
+func unixTime(ts *big.Int) time.Time {
+	return time.Unix(ts.Int64(), 0).UTC()
+}
+
+// processPollCreatedEvent indexes a new poll, reading the fields that are
+// not in the event from the poll contract
+func (l *Listener) processPollCreatedEvent(ctx context.Context, vLog types.Log) error {
+	var ev struct {
+		Question string
+		Duration *big.Int
+	}
+	if err := unpackEvent(factoryContractABI, "PollCreated", vLog, &ev); err != nil {
+		return err
+	}
+
+	pollAddress, err := topicAddress(vLog, 2)
+	if err != nil {
+		return err
+	}
+	creator, err := topicAddress(vLog, 3)
+	if err != nil {
+		return err
+	}
+	l.polls[pollAddress] = true
+	factory := database.Address(vLog.Address)
+
+	options, err := l.call(ctx, pollAddress, pollContractABI, "options")
+	if err != nil {
+		return err
+	}
+	root, err := l.call(ctx, pollAddress, pollContractABI, "voterMerkleRoot")
+	if err != nil {
+		return err
+	}
+	createdAt, err := l.call(ctx, pollAddress, pollContractABI, "createdAt")
+	if err != nil {
+		return err
+	}
+	endTime, err := l.call(ctx, pollAddress, pollContractABI, "endTime")
+	if err != nil {
+		return err
+	}
+
+	poll := &database.Poll{
+		Factory:         &factory,
+		ContractAddress: database.Address(pollAddress),
+		Question:        ev.Question,
+		Options:         options[0].([]string),
+		Duration:        int(ev.Duration.Int64()),
+		VoterMerkleRoot: common.Hash(root[0].([32]byte)).Hex(),
+		CreatedAt:       unixTime(createdAt[0].(*big.Int)),
+		ClosesAt:        unixTime(endTime[0].(*big.Int)),
+		State:           "active",
+		Creator:         database.Address(creator),
+		BlockNumber:     int64(vLog.BlockNumber),
+		TransactionHash: vLog.TxHash.Hex(),
+		Tags:            []string{},
+	}
+
+	log.Printf("Indexing poll %s at block %d\n", pollAddress.Hex(), vLog.BlockNumber)
+	return l.db.CreatePoll(ctx, poll)
+}
+
 // processVoteCommittedEvent processes a VoteCommitted event
 func (l *Listener) processVoteCommittedEvent(ctx context.Context, vLog types.Log) error {
-	// TODO: Parse event data using ABI
-	log.Printf("Processing VoteCommitted event at block %d\n", vLog.BlockNumber)
-	return nil
+	var ev struct {
+		Commitment [32]byte
+		Timestamp  *big.Int
+	}
+	if err := unpackEvent(pollContractABI, "VoteCommitted", vLog, &ev); err != nil {
+		return err
+	}
+
+	voter, err := topicAddress(vLog, 1)
+	if err != nil {
+		return err
+	}
+
+	vote := &database.Vote{
+		PollAddress:     database.Address(vLog.Address),
+		Voter:           database.Address(voter),
+		Commitment:      common.Hash(ev.Commitment).Hex(),
+		CommittedAt:     unixTime(ev.Timestamp),
+		BlockNumber:     int64(vLog.BlockNumber),
+		TransactionHash: vLog.TxHash.Hex(),
+	}
+	return l.db.CreateVote(ctx, vote)
 }
 
-// processVoteRevealedEvent processes a VoteRevealed event
+// processVoteRevealedEvent marks the vote revealed and counts it towards
+// the poll's provisional tally
 func (l *Listener) processVoteRevealedEvent(ctx context.Context, vLog types.Log) error {
-	// TODO: Parse event data using ABI
-	log.Printf("Processing VoteRevealed event at block %d\n", vLog.BlockNumber)
-	return nil
+	var ev struct {
+		Choice    *big.Int
+		Timestamp *big.Int
+	}
+	if err := unpackEvent(pollContractABI, "VoteRevealed", vLog, &ev); err != nil {
+		return err
+	}
+
+	voter, err := topicAddress(vLog, 1)
+	if err != nil {
+		return err
+	}
+
+	pollAddress := database.Address(vLog.Address)
+	choice := int(ev.Choice.Int64())
+
+	if _, err := l.db.RecordProvisionalReveal(ctx, pollAddress, database.Address(voter), choice, int64(vLog.BlockNumber)); err != nil {
+		return err
+	}
+
+	return l.db.RevealVote(ctx, pollAddress, database.Address(voter), choice, nil, unixTime(ev.Timestamp), int64(vLog.BlockNumber))
 }
 
 // processPollClosedEvent processes a PollClosed event
 func (l *Listener) processPollClosedEvent(ctx context.Context, vLog types.Log) error {
-	// TODO: Parse event data using ABI
 	log.Printf("Processing PollClosed event at block %d\n", vLog.BlockNumber)
 
+	var ev struct {
+		Timestamp *big.Int
+	}
+	if err := unpackEvent(pollContractABI, "PollClosed", vLog, &ev); err != nil {
+		return err
+	}
+
 	// Update poll state to closed
-	pollAddress := vLog.Address.Hex()
-	return l.db.UpdatePollState(ctx, pollAddress, "closed")
+	pollAddress := database.Address(vLog.Address)
+	if err := l.db.UpdatePollState(ctx, pollAddress, "closed"); err != nil {
+		return err
+	}
+	if err := l.db.SetPollClosedAt(ctx, pollAddress, unixTime(ev.Timestamp)); err != nil {
+		return err
+	}
+
+	return l.notify(ctx, webhook.EventPollClosed, vLog, unixTime(ev.Timestamp), nil)
 }
 
-// processResultsTalliedEvent processes a ResultsTallied event
+// processResultsTalliedEvent stores the final results and checks them
+// against the provisional tally built from reveals
 func (l *Listener) processResultsTalliedEvent(ctx context.Context, vLog types.Log) error {
-	// TODO: Parse event data using ABI
-	log.Printf("Processing ResultsTallied event at block %d\n", vLog.BlockNumber)
+	var ev struct {
+		Results   []*big.Int
+		Timestamp *big.Int
+	}
+	if err := unpackEvent(pollContractABI, "ResultsTallied", vLog, &ev); err != nil {
+		return err
+	}
+
+	pollAddress := database.Address(vLog.Address)
+	log.Printf("Processing ResultsTallied event for %s at block %d\n", pollAddress.Hex(), vLog.BlockNumber)
+
+	result := &database.Result{
+		PollAddress:     pollAddress,
+		VoteCounts:      make([]int, len(ev.Results)),
+		TalliedAt:       unixTime(ev.Timestamp),
+		BlockNumber:     int64(vLog.BlockNumber),
+		TransactionHash: vLog.TxHash.Hex(),
+	}
+	for i, n := range ev.Results {
+		result.VoteCounts[i] = int(n.Int64())
+		result.TotalVotes += result.VoteCounts[i]
+	}
+
+	if err := l.db.CreateResult(ctx, result); err != nil {
+		return err
+	}
+
+	provisional, err := l.db.GetProvisionalTally(ctx, pollAddress)
+	if err != nil {
+		return err
+	}
+	if provisional.RevealedVotes > 0 {
+		match := provisional.Matches(result.VoteCounts)
+		if !match {
+			l.metrics.ProvisionalMismatches.Inc()
+			log.Printf("Warning: provisional tally %v for %s does not match final results %v\n",
+				provisional.VoteCounts(len(result.VoteCounts)), pollAddress.Hex(), result.VoteCounts)
+		}
+		if err := l.db.SetProvisionalMatch(ctx, pollAddress, match); err != nil {
+			return err
+		}
+	}
 
 	// Update poll state to tallied
-	pollAddress := vLog.Address.Hex()
-	return l.db.UpdatePollState(ctx, pollAddress, "tallied")
+	if err := l.db.UpdatePollState(ctx, pollAddress, "tallied"); err != nil {
+		return err
+	}
+
+	return l.notify(ctx, webhook.EventPollTallied, vLog, result.TalliedAt, result)
+}
+
+// notify queues webhook deliveries for a poll state transition. The poll is
+// re-read so subscribers receive its updated state.
+func (l *Listener) notify(ctx context.Context, eventType string, vLog types.Log, occurredAt time.Time, result *database.Result) error {
+	poll, err := l.db.GetPollByAddress(ctx, database.Address(vLog.Address))
+	if err != nil {
+		return err
+	}
+	if poll == nil {
+		return nil
+	}
+
+	queued, err := webhook.Enqueue(ctx, l.db, &webhook.Payload{
+		Type:            eventType,
+		OccurredAt:      occurredAt,
+		BlockNumber:     int64(vLog.BlockNumber),
+		TransactionHash: vLog.TxHash.Hex(),
+		Poll:            poll,
+		Result:          result,
+	})
+	if err != nil {
+		return err
+	}
+	if queued > 0 {
+		log.Printf("Queued %d %s webhook deliveries for %s\n", queued, eventType, poll.ContractAddress.Hex())
+	}
+
+	return nil
 }
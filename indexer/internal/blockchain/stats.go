@@ -0,0 +1,23 @@
+package blockchain
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// refreshStats refreshes the platform statistics views if events were
+// handled since the last refresh
+func (l *Listener) refreshStats(ctx context.Context) {
+	if !l.statsStale {
+		return
+	}
+
+	start := time.Now()
+	if err := l.db.RefreshPlatformStats(ctx); err != nil {
+		log.Printf("Warning: %v\n", err)
+		return
+	}
+	l.metrics.StatsRefreshDuration.Observe(time.Since(start).Seconds())
+	l.statsStale = false
+}
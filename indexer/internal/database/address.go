@@ -0,0 +1,73 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Address is an Ethereum address in canonical form.
+// It is stored as lowercase hex and rendered in EIP-55 checksummed form.
+type Address common.Address
+
+// ParseAddress validates a hex address and returns its canonical form
+func ParseAddress(s string) (Address, error) {
+	if !common.IsHexAddress(s) {
+		return Address{}, fmt.Errorf("invalid address: %q", s)
+	}
+	return Address(common.HexToAddress(s)), nil
+}
+
+// Hex returns the EIP-55 checksummed representation
+func (a Address) Hex() string {
+	return common.Address(a).Hex()
+}
+
+// Lower returns the lowercase representation used for storage and cache keys
+func (a Address) Lower() string {
+	return strings.ToLower(common.Address(a).Hex())
+}
+
+// String implements fmt.Stringer
+func (a Address) String() string {
+	return a.Hex()
+}
+
+// MarshalText renders the address in checksummed form
+func (a Address) MarshalText() ([]byte, error) {
+	return []byte(a.Hex()), nil
+}
+
+// UnmarshalText parses an address in any letter case
+func (a *Address) UnmarshalText(text []byte) error {
+	parsed, err := ParseAddress(string(text))
+	if err != nil {
+		return err
+	}
+	*a = parsed
+	return nil
+}
+
+// TextValue implements pgtype.TextValuer so addresses are stored lowercase
+func (a Address) TextValue() (pgtype.Text, error) {
+	return pgtype.Text{String: a.Lower(), Valid: true}, nil
+}
+
+// ScanText implements pgtype.TextScanner
+func (a *Address) ScanText(v pgtype.Text) error {
+	if !v.Valid {
+		return fmt.Errorf("cannot scan NULL into Address")
+	}
+	return a.UnmarshalText([]byte(v.String))
+}
+
+// addressStrings returns the storage form of addresses for use with ANY($1)
+func addressStrings(addresses []Address) []string {
+	out := make([]string, len(addresses))
+	for i, a := range addresses {
+		out[i] = a.Lower()
+	}
+	return out
+}
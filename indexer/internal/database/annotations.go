@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// GetPollAnnotation retrieves the annotation of a poll, or nil if it has none
+func (db *DB) GetPollAnnotation(ctx context.Context, pollAddress Address) (*PollAnnotation, error) {
+	query := `
+		SELECT poll_address, tags, notes, scenario_id, updated_by, created_at, updated_at
+		FROM poll_annotations
+		WHERE poll_address = $1 AND chain_id = $2
+	`
+
+	annotation := &PollAnnotation{}
+	err := db.Pool.QueryRow(ctx, query, pollAddress, ChainFrom(ctx)).Scan(
+		&annotation.PollAddress, &annotation.Tags, &annotation.Notes, &annotation.ScenarioID,
+		&annotation.UpdatedBy, &annotation.CreatedAt, &annotation.UpdatedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll annotation: %w", err)
+	}
+
+	return annotation, nil
+}
+
+// UpsertPollAnnotation creates or replaces the annotation of a poll
+func (db *DB) UpsertPollAnnotation(ctx context.Context, annotation *PollAnnotation) error {
+	query := `
+		INSERT INTO poll_annotations (poll_address, tags, notes, scenario_id, updated_by, chain_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (chain_id, poll_address) DO UPDATE
+		SET tags = EXCLUDED.tags,
+			notes = EXCLUDED.notes,
+			scenario_id = EXCLUDED.scenario_id,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = NOW()
+		RETURNING created_at, updated_at
+	`
+
+	err := db.Pool.QueryRow(
+		ctx, query,
+		annotation.PollAddress, annotation.Tags, annotation.Notes,
+		annotation.ScenarioID, annotation.UpdatedBy, ChainFrom(ctx),
+	).Scan(&annotation.CreatedAt, &annotation.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to save poll annotation: %w", err)
+	}
+
+	return nil
+}
+
+// DeletePollAnnotation removes the annotation of a poll. It reports false
+// if the poll had none.
+func (db *DB) DeletePollAnnotation(ctx context.Context, pollAddress Address) (bool, error) {
+	query := `DELETE FROM poll_annotations WHERE poll_address = $1 AND chain_id = $2`
+
+	tag, err := db.Pool.Exec(ctx, query, pollAddress, ChainFrom(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete poll annotation: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateAPIKey inserts a new API key
+func (db *DB) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	query := `
+		INSERT INTO api_keys (name, key_prefix, key_hash, scopes, rate_limit)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at
+	`
+
+	err := db.Pool.QueryRow(
+		ctx, query,
+		key.Name, key.KeyPrefix, key.KeyHash, key.Scopes, key.RateLimit,
+	).Scan(&key.ID, &key.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create api key: %w", err)
+	}
+
+	return nil
+}
+
+// EnsureAPIKey inserts an API key if its hash is not already present.
+// Used to bootstrap the admin key from the environment.
+func (db *DB) EnsureAPIKey(ctx context.Context, key *APIKey) error {
+	query := `
+		INSERT INTO api_keys (name, key_prefix, key_hash, scopes, rate_limit)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (key_hash) DO UPDATE
+		SET scopes = EXCLUDED.scopes, revoked_at = NULL
+		RETURNING id, created_at
+	`
+
+	err := db.Pool.QueryRow(
+		ctx, query,
+		key.Name, key.KeyPrefix, key.KeyHash, key.Scopes, key.RateLimit,
+	).Scan(&key.ID, &key.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to ensure api key: %w", err)
+	}
+
+	return nil
+}
+
+// GetActiveAPIKeyByHash retrieves a non-revoked API key by the hash of its plaintext
+func (db *DB) GetActiveAPIKeyByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	query := `
+		SELECT id, name, key_prefix, key_hash, scopes, rate_limit,
+			created_at, last_used_at, revoked_at
+		FROM api_keys
+		WHERE key_hash = $1 AND revoked_at IS NULL
+	`
+
+	key := &APIKey{}
+	err := db.Pool.QueryRow(ctx, query, keyHash).Scan(
+		&key.ID, &key.Name, &key.KeyPrefix, &key.KeyHash, &key.Scopes,
+		&key.RateLimit, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	return key, nil
+}
+
+// ListAPIKeys retrieves all API keys, including revoked ones
+func (db *DB) ListAPIKeys(ctx context.Context) ([]*APIKey, error) {
+	query := `
+		SELECT id, name, key_prefix, key_hash, scopes, rate_limit,
+			created_at, last_used_at, revoked_at
+		FROM api_keys
+		ORDER BY created_at DESC
+	`
+
+	rows, err := db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	defer rows.Close()
+
+	keys := []*APIKey{}
+	for rows.Next() {
+		key := &APIKey{}
+		err := rows.Scan(
+			&key.ID, &key.Name, &key.KeyPrefix, &key.KeyHash, &key.Scopes,
+			&key.RateLimit, &key.CreatedAt, &key.LastUsedAt, &key.RevokedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return keys, nil
+}
+
+// TouchAPIKey records that an API key was just used
+func (db *DB) TouchAPIKey(ctx context.Context, id int) error {
+	query := `UPDATE api_keys SET last_used_at = NOW() WHERE id = $1`
+
+	if _, err := db.Pool.Exec(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to update api key usage: %w", err)
+	}
+
+	return nil
+}
+
+// RevokeAPIKey marks an API key as revoked.
+// It reports false if no active key with that ID exists.
+func (db *DB) RevokeAPIKey(ctx context.Context, id int) (bool, error) {
+	query := `UPDATE api_keys SET revoked_at = NOW() WHERE id = $1 AND revoked_at IS NULL`
+
+	result, err := db.Pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
@@ -0,0 +1,19 @@
+package database
+
+import "context"
+
+type chainKey struct{}
+
+// WithChain scopes ctx to a chain. Every query on indexed data reads and
+// writes the rows of the chain its context is scoped to; API keys and
+// voter trees are shared by all chains.
+func WithChain(ctx context.Context, chainID int64) context.Context {
+	return context.WithValue(ctx, chainKey{}, chainID)
+}
+
+// ChainFrom returns the chain ctx is scoped to, or 0 if it is not scoped,
+// which matches no indexed rows
+func ChainFrom(ctx context.Context) int64 {
+	chainID, _ := ctx.Value(chainKey{}).(int64)
+	return chainID
+}
@@ -0,0 +1,282 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Indexer command kinds and states
+const (
+	CommandReindexRange = "reindex_range"
+	CommandReindexPoll  = "reindex_poll"
+
+	CommandPending = "pending"
+	CommandRunning = "running"
+	CommandDone    = "done"
+	CommandFailed  = "failed"
+)
+
+const commandColumns = `
+	id, chain_id, factory, kind, from_block, to_block, poll_address, status, error,
+	created_at, started_at, finished_at
+`
+
+// GetIndexerControl retrieves the operator switch of the chain. A chain
+// without a control row reports its listeners as running.
+func (db *DB) GetIndexerControl(ctx context.Context) (*IndexerControl, error) {
+	query := `SELECT paused, updated_at FROM indexer_control WHERE chain_id = $1`
+
+	control := &IndexerControl{}
+	err := db.Pool.QueryRow(ctx, query, ChainFrom(ctx)).Scan(&control.Paused, &control.UpdatedAt)
+	if err == pgx.ErrNoRows {
+		return &IndexerControl{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get indexer control: %w", err)
+	}
+
+	return control, nil
+}
+
+// SetIndexerPaused pauses or resumes block processing on the chain
+func (db *DB) SetIndexerPaused(ctx context.Context, paused bool) (*IndexerControl, error) {
+	query := `
+		INSERT INTO indexer_control (chain_id, paused, updated_at)
+		VALUES ($2, $1, NOW())
+		ON CONFLICT (chain_id) DO UPDATE
+		SET paused = EXCLUDED.paused, updated_at = EXCLUDED.updated_at
+		RETURNING paused, updated_at
+	`
+
+	control := &IndexerControl{}
+	if err := db.Pool.QueryRow(ctx, query, paused, ChainFrom(ctx)).Scan(&control.Paused, &control.UpdatedAt); err != nil {
+		return nil, fmt.Errorf("failed to update indexer control: %w", err)
+	}
+
+	return control, nil
+}
+
+// CreateIndexerCommand queues a command for the listener of cmd.Factory
+// on the chain
+func (db *DB) CreateIndexerCommand(ctx context.Context, cmd *IndexerCommand) error {
+	query := `
+		INSERT INTO indexer_commands (kind, from_block, to_block, poll_address, chain_id, factory)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + commandColumns
+
+	err := db.Pool.QueryRow(ctx, query, cmd.Kind, cmd.FromBlock, cmd.ToBlock, cmd.PollAddress,
+		ChainFrom(ctx), cmd.Factory).Scan(commandFields(cmd)...)
+	if err != nil {
+		return fmt.Errorf("failed to create indexer command: %w", err)
+	}
+
+	return nil
+}
+
+// QueuePollReindex queues a poll reindex unless one from the same block is
+// already pending, running or done for the poll, and reports whether it
+// queued one. Only a failed reindex is queued again.
+func (db *DB) QueuePollReindex(ctx context.Context, cmd *IndexerCommand) (bool, error) {
+	query := `
+		INSERT INTO indexer_commands (kind, from_block, to_block, poll_address, chain_id, factory)
+		SELECT $1::varchar, $2::bigint, $3::bigint, $4::varchar, $7::bigint, $8::varchar
+		WHERE NOT EXISTS (
+			SELECT 1 FROM indexer_commands
+			WHERE chain_id = $7 AND kind = $1::varchar AND poll_address = $4::varchar AND from_block = $2::bigint
+				AND status IN ($5, $6, $9)
+		)
+		RETURNING ` + commandColumns
+
+	cmd.Kind = CommandReindexPoll
+	err := db.Pool.QueryRow(ctx, query, cmd.Kind, cmd.FromBlock, cmd.ToBlock, cmd.PollAddress,
+		CommandPending, CommandRunning, ChainFrom(ctx), cmd.Factory, CommandDone).Scan(commandFields(cmd)...)
+	if err == pgx.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to queue poll reindex: %w", err)
+	}
+
+	return true, nil
+}
+
+// ListIndexerCommands retrieves the chain's most recent commands, newest first
+func (db *DB) ListIndexerCommands(ctx context.Context, limit int) ([]*IndexerCommand, error) {
+	query := `SELECT ` + commandColumns + ` FROM indexer_commands WHERE chain_id = $2 ORDER BY id DESC LIMIT $1`
+
+	rows, err := db.Pool.Query(ctx, query, limit, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexer commands: %w", err)
+	}
+	defer rows.Close()
+
+	commands := []*IndexerCommand{}
+	for rows.Next() {
+		cmd := &IndexerCommand{}
+		if err := rows.Scan(commandFields(cmd)...); err != nil {
+			return nil, fmt.Errorf("failed to scan indexer command: %w", err)
+		}
+		commands = append(commands, cmd)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return commands, nil
+}
+
+// ClaimIndexerCommand marks the oldest pending command for factory on the
+// chain as running and returns it, or nil if none is pending. Commands
+// queued without a factory go to whichever of the chain's listeners asks first.
+func (db *DB) ClaimIndexerCommand(ctx context.Context, factory Address) (*IndexerCommand, error) {
+	query := `
+		UPDATE indexer_commands
+		SET status = 'running', started_at = NOW()
+		WHERE id = (
+			SELECT id FROM indexer_commands
+			WHERE status = 'pending' AND chain_id = $1 AND (factory IS NULL OR factory = $2)
+			ORDER BY id
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + commandColumns
+
+	cmd := &IndexerCommand{}
+	err := db.Pool.QueryRow(ctx, query, ChainFrom(ctx), factory).Scan(commandFields(cmd)...)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim indexer command: %w", err)
+	}
+
+	return cmd, nil
+}
+
+// FinishIndexerCommand records the outcome of a command
+func (db *DB) FinishIndexerCommand(ctx context.Context, id int64, cmdErr error) error {
+	status := CommandDone
+	var message *string
+	if cmdErr != nil {
+		status = CommandFailed
+		msg := cmdErr.Error()
+		message = &msg
+	}
+
+	query := `
+		UPDATE indexer_commands
+		SET status = $2, error = $3, finished_at = NOW()
+		WHERE id = $1
+	`
+
+	if _, err := db.Pool.Exec(ctx, query, id, status, message); err != nil {
+		return fmt.Errorf("failed to finish indexer command: %w", err)
+	}
+
+	return nil
+}
+
+// RequeueRunningIndexerCommands returns the commands of factory's listener
+// that were interrupted by an indexer restart to the queue
+func (db *DB) RequeueRunningIndexerCommands(ctx context.Context, factory Address) (int, error) {
+	query := `
+		UPDATE indexer_commands SET status = 'pending', started_at = NULL
+		WHERE status = 'running' AND chain_id = $1 AND (factory IS NULL OR factory = $2)
+	`
+
+	result, err := db.Pool.Exec(ctx, query, ChainFrom(ctx), factory)
+	if err != nil {
+		return 0, fmt.Errorf("failed to requeue indexer commands: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// RecordFailedEvent stores or updates the failure of an event handler
+func (db *DB) RecordFailedEvent(ctx context.Context, event *Event, handlerErr error) error {
+	query := `
+		INSERT INTO failed_events (
+			contract_address, event_name, block_number, transaction_hash, log_index, error, chain_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO UPDATE
+		SET error = EXCLUDED.error,
+			attempts = failed_events.attempts + 1,
+			last_failed_at = NOW(),
+			resolved_at = NULL
+	`
+
+	_, err := db.Pool.Exec(
+		ctx, query,
+		event.ContractAddress, event.EventName, event.BlockNumber,
+		event.TransactionHash, event.LogIndex, handlerErr.Error(), ChainFrom(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record failed event: %w", err)
+	}
+
+	return nil
+}
+
+// ResolveFailedEvent marks a previously failed event as handled
+func (db *DB) ResolveFailedEvent(ctx context.Context, transactionHash string, logIndex int) error {
+	query := `
+		UPDATE failed_events
+		SET resolved_at = NOW()
+		WHERE chain_id = $3 AND transaction_hash = $1 AND log_index = $2 AND resolved_at IS NULL
+	`
+
+	if _, err := db.Pool.Exec(ctx, query, transactionHash, logIndex, ChainFrom(ctx)); err != nil {
+		return fmt.Errorf("failed to resolve failed event: %w", err)
+	}
+
+	return nil
+}
+
+// ListFailedEvents retrieves the chain's failed events in block order, optionally
+// including those a reindex has since resolved
+func (db *DB) ListFailedEvents(ctx context.Context, includeResolved bool, limit int) ([]*FailedEvent, error) {
+	query := `
+		SELECT id, contract_address, event_name, block_number, transaction_hash,
+			log_index, error, attempts, first_failed_at, last_failed_at, resolved_at
+		FROM failed_events
+		WHERE chain_id = $3 AND ($1 OR resolved_at IS NULL)
+		ORDER BY block_number ASC, log_index ASC
+		LIMIT $2
+	`
+
+	rows, err := db.Pool.Query(ctx, query, includeResolved, limit, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list failed events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*FailedEvent{}
+	for rows.Next() {
+		event := &FailedEvent{}
+		err := rows.Scan(
+			&event.ID, &event.ContractAddress, &event.EventName, &event.BlockNumber,
+			&event.TransactionHash, &event.LogIndex, &event.Error, &event.Attempts,
+			&event.FirstFailedAt, &event.LastFailedAt, &event.ResolvedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan failed event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+func commandFields(cmd *IndexerCommand) []any {
+	return []any{
+		&cmd.ID, &cmd.ChainID, &cmd.Factory, &cmd.Kind, &cmd.FromBlock, &cmd.ToBlock, &cmd.PollAddress,
+		&cmd.Status, &cmd.Error, &cmd.CreatedAt, &cmd.StartedAt, &cmd.FinishedAt,
+	}
+}
@@ -3,6 +3,8 @@ package database
 import (
 	"context"
 	"fmt"
+
+	"github.com/jackc/pgx/v5"
 )
 
 // CreateEvent inserts a new event into the database
@@ -10,9 +12,9 @@ func (db *DB) CreateEvent(ctx context.Context, event *Event) error {
 	query := `
 		INSERT INTO events (
 			contract_address, event_name, event_data, block_number,
-			block_hash, transaction_hash, log_index
-		) VALUES ($1, $2, $3, $4, $5, $6, $7)
-		ON CONFLICT (transaction_hash, log_index) DO NOTHING
+			block_hash, transaction_hash, log_index, gas_used, chain_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (chain_id, transaction_hash, log_index) DO NOTHING
 		RETURNING id, created_timestamp
 	`
 
@@ -20,7 +22,7 @@ func (db *DB) CreateEvent(ctx context.Context, event *Event) error {
 		ctx, query,
 		event.ContractAddress, event.EventName, event.EventData,
 		event.BlockNumber, event.BlockHash, event.TransactionHash,
-		event.LogIndex,
+		event.LogIndex, event.GasUsed, ChainFrom(ctx),
 	).Scan(&event.ID, &event.CreatedTimestamp)
 
 	// Ignore duplicate key errors (event already processed)
@@ -31,15 +33,239 @@ func (db *DB) CreateEvent(ctx context.Context, event *Event) error {
 	return nil
 }
 
-// GetLastProcessedBlock retrieves the highest block number processed
+// GetLastProcessedBlock retrieves the highest block number with an
+// indexed event on the chain
 func (db *DB) GetLastProcessedBlock(ctx context.Context) (int64, error) {
-	query := `SELECT COALESCE(MAX(block_number), 0) FROM events`
+	query := `SELECT COALESCE(MAX(block_number), 0) FROM events WHERE chain_id = $1`
+
+	var blockNumber int64
+	err := db.Pool.QueryRow(ctx, query, ChainFrom(ctx)).Scan(&blockNumber)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last processed block: %w", err)
+	}
+
+	return blockNumber, nil
+}
+
+// GetFactoryLastBlock retrieves the highest block number with an indexed
+// event from a factory or the polls it deployed. Each listener resumes
+// from its own factory's progress, so a factory added to a chain that is
+// already indexed still has its history read.
+func (db *DB) GetFactoryLastBlock(ctx context.Context, factory Address) (int64, error) {
+	query := `
+		SELECT COALESCE(MAX(block_number), 0)
+		FROM events
+		WHERE chain_id = $2 AND (contract_address = $1 OR contract_address IN (
+			SELECT contract_address FROM polls WHERE chain_id = $2 AND factory = $1
+		))
+	`
 
 	var blockNumber int64
-	err := db.Pool.QueryRow(ctx, query).Scan(&blockNumber)
+	err := db.Pool.QueryRow(ctx, query, factory, ChainFrom(ctx)).Scan(&blockNumber)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get last processed block: %w", err)
 	}
 
 	return blockNumber, nil
 }
+
+// GetFirstEventBlock returns the block of the first event with the given name
+// emitted by a contract, or nil if it has not been indexed
+func (db *DB) GetFirstEventBlock(ctx context.Context, address Address, eventName string) (*int64, error) {
+	query := `
+		SELECT MIN(block_number)
+		FROM events
+		WHERE contract_address = $1 AND event_name = $2 AND chain_id = $3
+	`
+
+	var blockNumber *int64
+	if err := db.Pool.QueryRow(ctx, query, address, eventName, ChainFrom(ctx)).Scan(&blockNumber); err != nil {
+		return nil, fmt.Errorf("failed to get event block: %w", err)
+	}
+
+	return blockNumber, nil
+}
+
+// ListEventsByContracts retrieves the raw events emitted by several contracts
+// in one query, grouped by contract and in chain order
+func (db *DB) ListEventsByContracts(ctx context.Context, addresses []Address) (map[Address][]*Event, error) {
+	query := `
+		SELECT id, contract_address, event_name, event_data, block_number,
+			block_hash, transaction_hash, log_index, gas_used, created_timestamp
+		FROM events
+		WHERE contract_address = ANY($1) AND chain_id = $2
+		ORDER BY block_number ASC, log_index ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, addressStrings(addresses), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	events, err := scanEvents(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[Address][]*Event, len(addresses))
+	for _, event := range events {
+		grouped[event.ContractAddress] = append(grouped[event.ContractAddress], event)
+	}
+	return grouped, nil
+}
+
+// StreamPollEvents calls fn for every event of a poll in chain order,
+// including the factory's PollCreated event, without loading them all into memory
+func (db *DB) StreamPollEvents(ctx context.Context, poll *Poll, fn func(*Event) error) error {
+	query := `
+		SELECT id, contract_address, event_name, event_data, block_number,
+			block_hash, transaction_hash, log_index, gas_used, created_timestamp
+		FROM events
+		WHERE chain_id = $3 AND (contract_address = $1
+			OR (event_name = 'PollCreated' AND transaction_hash = $2))
+		ORDER BY block_number ASC, log_index ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, poll.ContractAddress, poll.TransactionHash, ChainFrom(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to stream events: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(event); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	return nil
+}
+
+// ListEventsUpToBlock retrieves the events a contract emitted at or before
+// a block, in chain order
+func (db *DB) ListEventsUpToBlock(ctx context.Context, address Address, block int64) ([]*Event, error) {
+	query := `
+		SELECT id, contract_address, event_name, event_data, block_number,
+			block_hash, transaction_hash, log_index, gas_used, created_timestamp
+		FROM events
+		WHERE contract_address = $1 AND block_number <= $2 AND chain_id = $3
+		ORDER BY block_number ASC, log_index ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, address, block, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return scanEvents(rows)
+}
+
+// ListEventsByTransaction retrieves the events a transaction emitted, in log order
+func (db *DB) ListEventsByTransaction(ctx context.Context, txHash string) ([]*Event, error) {
+	query := `
+		SELECT id, contract_address, event_name, event_data, block_number,
+			block_hash, transaction_hash, log_index, gas_used, created_timestamp
+		FROM events
+		WHERE transaction_hash = $1 AND chain_id = $2
+		ORDER BY log_index ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, txHash, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return scanEvents(rows)
+}
+
+// ListEventsByBlock retrieves the events indexed in a block, in log order
+func (db *DB) ListEventsByBlock(ctx context.Context, block int64) ([]*Event, error) {
+	query := `
+		SELECT id, contract_address, event_name, event_data, block_number,
+			block_hash, transaction_hash, log_index, gas_used, created_timestamp
+		FROM events
+		WHERE block_number = $1 AND chain_id = $2
+		ORDER BY log_index ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, block, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return scanEvents(rows)
+}
+
+// scanEvents reads every row of an events query and closes rows
+func scanEvents(rows pgx.Rows) ([]*Event, error) {
+	defer rows.Close()
+
+	events := []*Event{}
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return events, nil
+}
+
+func scanEvent(rows pgx.Rows) (*Event, error) {
+	event := &Event{}
+	err := rows.Scan(
+		&event.ID, &event.ContractAddress, &event.EventName, &event.EventData,
+		&event.BlockNumber, &event.BlockHash, &event.TransactionHash,
+		&event.LogIndex, &event.GasUsed, &event.CreatedTimestamp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan event: %w", err)
+	}
+	return event, nil
+}
+
+// ListEventsAfterID retrieves up to limit events a contract emitted that
+// were stored after the event with the given ID, in storage order
+func (db *DB) ListEventsAfterID(ctx context.Context, address Address, afterID, limit int) ([]*Event, error) {
+	query := `
+		SELECT id, contract_address, event_name, event_data, block_number,
+			block_hash, transaction_hash, log_index, gas_used, created_timestamp
+		FROM events
+		WHERE contract_address = $1 AND id > $2 AND chain_id = $4
+		ORDER BY id ASC
+		LIMIT $3
+	`
+
+	rows, err := db.Pool.Query(ctx, query, address, afterID, limit, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list events: %w", err)
+	}
+
+	return scanEvents(rows)
+}
+
+// GetLastEventID returns the ID of the newest stored event of a contract,
+// or 0 if none has been stored
+func (db *DB) GetLastEventID(ctx context.Context, address Address) (int, error) {
+	query := `SELECT COALESCE(MAX(id), 0) FROM events WHERE contract_address = $1 AND chain_id = $2`
+
+	var id int
+	if err := db.Pool.QueryRow(ctx, query, address, ChainFrom(ctx)).Scan(&id); err != nil {
+		return 0, fmt.Errorf("failed to get last event id: %w", err)
+	}
+
+	return id, nil
+}
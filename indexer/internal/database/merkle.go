@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateMerkleTree stores a tree and the proof of every eligible voter.
+// Roots are derived from the voter list, so registering a root that
+// already exists is a no-op; the stored tree is returned with created false.
+func (db *DB) CreateMerkleTree(ctx context.Context, tree *MerkleTree, proofs []*MerkleProof) (bool, error) {
+	tx, err := db.Pool.Begin(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO merkle_trees (root, eligible_count)
+		VALUES ($1, $2)
+		ON CONFLICT (root) DO NOTHING
+		RETURNING created_at
+	`
+
+	err = tx.QueryRow(ctx, query, tree.Root, tree.EligibleCount).Scan(&tree.CreatedAt)
+	if err == pgx.ErrNoRows {
+		existing, err := db.GetMerkleTree(ctx, tree.Root)
+		if err != nil {
+			return false, err
+		}
+		*tree = *existing
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create merkle tree: %w", err)
+	}
+
+	_, err = tx.CopyFrom(
+		ctx,
+		pgx.Identifier{"merkle_proofs"},
+		[]string{"root", "voter", "leaf", "proof"},
+		pgx.CopyFromSlice(len(proofs), func(i int) ([]any, error) {
+			p := proofs[i]
+			return []any{p.Root, p.Voter.Lower(), p.Leaf, p.Proof}, nil
+		}),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to store merkle proofs: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return false, fmt.Errorf("failed to commit merkle tree: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetMerkleTree retrieves a registered tree by root
+func (db *DB) GetMerkleTree(ctx context.Context, root string) (*MerkleTree, error) {
+	query := `SELECT root, eligible_count, created_at FROM merkle_trees WHERE root = $1`
+
+	tree := &MerkleTree{}
+	err := db.Pool.QueryRow(ctx, query, root).Scan(&tree.Root, &tree.EligibleCount, &tree.CreatedAt)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merkle tree: %w", err)
+	}
+
+	return tree, nil
+}
+
+// GetMerkleProof retrieves the stored proof for a voter, or nil if the
+// voter is not in the tree
+func (db *DB) GetMerkleProof(ctx context.Context, root string, voter Address) (*MerkleProof, error) {
+	query := `SELECT root, voter, leaf, proof FROM merkle_proofs WHERE root = $1 AND voter = $2`
+
+	proof := &MerkleProof{}
+	err := db.Pool.QueryRow(ctx, query, root, voter).Scan(&proof.Root, &proof.Voter, &proof.Leaf, &proof.Proof)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merkle proof: %w", err)
+	}
+
+	return proof, nil
+}
+
+// GetEligibleCountByPoll returns the eligible voter count of the tree
+// registered for a poll's voterMerkleRoot, or nil if none is registered
+func (db *DB) GetEligibleCountByPoll(ctx context.Context, pollAddress Address) (*int, error) {
+	query := `
+		SELECT t.eligible_count
+		FROM polls p
+		JOIN merkle_trees t ON t.root = p.voter_merkle_root
+		WHERE p.chain_id = $2 AND p.contract_address = $1
+	`
+
+	var count int
+	err := db.Pool.QueryRow(ctx, query, pollAddress, ChainFrom(ctx)).Scan(&count)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eligible count: %w", err)
+	}
+
+	return &count, nil
+}
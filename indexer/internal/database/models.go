@@ -1,63 +1,310 @@
 package database
 
 import (
+	"encoding/json"
 	"time"
 )
 
 // Poll represents a poll in the database
 type Poll struct {
-	ID                int       `json:"id"`
-	ContractAddress   string    `json:"contract_address"`
-	Question          string    `json:"question"`
-	Options           []string  `json:"options"`
-	Duration          int       `json:"duration"`
-	VoterMerkleRoot   string    `json:"voter_merkle_root"`
-	CreatedAt         time.Time `json:"created_at"`
-	ClosesAt          time.Time `json:"closes_at"`
-	State             string    `json:"state"`
-	Creator           string    `json:"creator"`
-	BlockNumber       int64     `json:"block_number"`
-	TransactionHash   string    `json:"transaction_hash"`
-	CreatedTimestamp  time.Time `json:"created_timestamp"`
+	ID               int       `json:"id"`
+	ChainID          int64     `json:"chain_id"`
+	Factory          *Address  `json:"factory,omitempty"` // Nil if the poll was indexed without its creation event
+	ContractAddress  Address   `json:"contract_address"`
+	Question         string    `json:"question"`
+	Options          []string  `json:"options"`
+	Duration         int       `json:"duration"`
+	VoterMerkleRoot  string    `json:"voter_merkle_root"`
+	CreatedAt        time.Time `json:"created_at"`
+	ClosesAt         time.Time `json:"closes_at"`
+	State            string    `json:"state"`
+	Creator          Address   `json:"creator"`
+	BlockNumber      int64     `json:"block_number"`
+	TransactionHash  string    `json:"transaction_hash"`
+	CreatedTimestamp time.Time `json:"created_timestamp"`
+
+	// Annotation tags; empty when the poll has none
+	Tags []string `json:"tags"`
+
+	// Derived from State and ClosesAt by SetChainTime; not stored
+	Status         string `json:"status,omitempty"`
+	OverdueSeconds *int64 `json:"overdue_seconds,omitempty"`
+}
+
+// Derived poll statuses. They extend the on-chain states with overdue: a
+// poll still active although the chain has passed its closing time, which
+// happens when the oracle fails to close it.
+const (
+	StatusActive  = "active"
+	StatusOverdue = "overdue"
+	StatusClosed  = "closed"
+	StatusTallied = "tallied"
+)
+
+// SetChainTime derives Status and OverdueSeconds from the chain's current time
+func (p *Poll) SetChainTime(now time.Time) {
+	p.Status = p.State
+	p.OverdueSeconds = nil
+	if p.State == StatusActive && !now.Before(p.ClosesAt) {
+		overdue := int64(now.Sub(p.ClosesAt) / time.Second)
+		p.Status = StatusOverdue
+		p.OverdueSeconds = &overdue
+	}
+}
+
+// PollVersion identifies the indexed data of a poll for conditional
+// requests. It moves whenever a block touches the poll or the off-chain
+// data shown with it (annotations, the voter tree) changes.
+type PollVersion struct {
+	State       string
+	ClosesAt    time.Time
+	LastBlock   int64      // Highest block with an indexed event for the poll
+	ModifiedAt  time.Time  // When any of the poll's data was last stored
+	AnnotatedAt *time.Time // Nil when the poll has no annotation
+	TreeAt      *time.Time // Nil when no voter tree is registered for the poll
+}
+
+// PollAnnotation is the off-chain labelling of a poll. A poll that was
+// never annotated reads as an empty annotation with no timestamps.
+type PollAnnotation struct {
+	PollAddress Address    `json:"poll_address"`
+	Tags        []string   `json:"tags"`
+	Notes       string     `json:"notes"`
+	ScenarioID  *string    `json:"scenario_id,omitempty"`
+	UpdatedBy   *string    `json:"updated_by,omitempty"` // API key name
+	CreatedAt   *time.Time `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time `json:"updated_at,omitempty"`
 }
 
 // Vote represents a vote in the database
 type Vote struct {
-	ID              int        `json:"id"`
-	PollAddress     string     `json:"poll_address"`
-	Voter           string     `json:"voter"`
-	Commitment      string     `json:"commitment"`
-	Choice          *int       `json:"choice,omitempty"`
-	Nonce           []byte     `json:"nonce,omitempty"`
-	Revealed        bool       `json:"revealed"`
-	CommittedAt     time.Time  `json:"committed_at"`
-	RevealedAt      *time.Time `json:"revealed_at,omitempty"`
-	BlockNumber     int64      `json:"block_number"`
-	TransactionHash string     `json:"transaction_hash"`
-	CreatedTimestamp time.Time `json:"created_timestamp"`
+	ID               int        `json:"id"`
+	PollAddress      Address    `json:"poll_address"`
+	Voter            Address    `json:"voter"`
+	Commitment       string     `json:"commitment"`
+	Choice           *int       `json:"choice,omitempty"`
+	Nonce            []byte     `json:"-"` // Commitment salt; never returned by the API
+	Revealed         bool       `json:"revealed"`
+	CommittedAt      time.Time  `json:"committed_at"`
+	RevealedAt       *time.Time `json:"revealed_at,omitempty"`
+	RevealedBlock    *int64     `json:"revealed_block,omitempty"`
+	BlockNumber      int64      `json:"block_number"`
+	TransactionHash  string     `json:"transaction_hash"`
+	CreatedTimestamp time.Time  `json:"created_timestamp"`
 }
 
 // Event represents a blockchain event in the database
 type Event struct {
-	ID              int       `json:"id"`
-	ContractAddress string    `json:"contract_address"`
-	EventName       string    `json:"event_name"`
-	EventData       string    `json:"event_data"` // JSONB stored as string
-	BlockNumber     int64     `json:"block_number"`
-	BlockHash       string    `json:"block_hash"`
-	TransactionHash string    `json:"transaction_hash"`
-	LogIndex        int       `json:"log_index"`
+	ID               int       `json:"id"`
+	ContractAddress  Address   `json:"contract_address"`
+	EventName        string    `json:"event_name"`
+	EventData        string    `json:"event_data"` // JSONB stored as string
+	BlockNumber      int64     `json:"block_number"`
+	BlockHash        string    `json:"block_hash"`
+	TransactionHash  string    `json:"transaction_hash"`
+	LogIndex         int       `json:"log_index"`
+	GasUsed          *int64    `json:"gas_used,omitempty"` // Gas used by the emitting transaction
 	CreatedTimestamp time.Time `json:"created_timestamp"`
 }
 
 // Result represents tallied poll results
 type Result struct {
-	ID              int       `json:"id"`
-	PollAddress     string    `json:"poll_address"`
-	VoteCounts      []int     `json:"vote_counts"`
-	TotalVotes      int       `json:"total_votes"`
-	TalliedAt       time.Time `json:"tallied_at"`
-	BlockNumber     int64     `json:"block_number"`
-	TransactionHash string    `json:"transaction_hash"`
+	ID               int       `json:"id"`
+	PollAddress      Address   `json:"poll_address"`
+	VoteCounts       []int     `json:"vote_counts"`
+	TotalVotes       int       `json:"total_votes"`
+	TalliedAt        time.Time `json:"tallied_at"`
+	BlockNumber      int64     `json:"block_number"`
+	TransactionHash  string    `json:"transaction_hash"`
+	ProvisionalMatch *bool     `json:"provisional_match,omitempty"` // Nil when no reveals were counted before tally
 	CreatedTimestamp time.Time `json:"created_timestamp"`
 }
+
+// ProvisionalTally is the running count of revealed votes for a poll
+// that has not been tallied on-chain
+type ProvisionalTally struct {
+	PollAddress   Address     `json:"poll_address"`
+	Counts        map[int]int `json:"-"` // Revealed votes by option index
+	RevealedVotes int         `json:"revealed_votes"`
+	LastBlock     int64       `json:"last_block"`
+	UpdatedAt     *time.Time  `json:"updated_at,omitempty"`
+}
+
+// VoteCounts returns the provisional counts as a slice with one entry per option
+func (t *ProvisionalTally) VoteCounts(options int) []int {
+	counts := make([]int, options)
+	for choice, n := range t.Counts {
+		if choice >= 0 && choice < options {
+			counts[choice] = n
+		}
+	}
+	return counts
+}
+
+// Matches reports whether the provisional counts equal final on-chain counts
+func (t *ProvisionalTally) Matches(final []int) bool {
+	total := 0
+	for choice, n := range final {
+		if t.Counts[choice] != n {
+			return false
+		}
+		total += n
+	}
+	return total == t.RevealedVotes
+}
+
+// APIKey represents an API key used to authenticate API requests
+type APIKey struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	KeyPrefix  string     `json:"key_prefix"`
+	KeyHash    string     `json:"-"`
+	Scopes     []string   `json:"scopes"`
+	RateLimit  *int       `json:"rate_limit,omitempty"` // Requests per window; nil uses the default
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// MerkleTree is a registered voter eligibility tree
+type MerkleTree struct {
+	Root          string    `json:"root"`
+	EligibleCount int       `json:"eligible_count"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// MerkleProof is the proof an eligible voter passes to commitVote
+type MerkleProof struct {
+	Root  string   `json:"root"`
+	Voter Address  `json:"voter"`
+	Leaf  string   `json:"leaf"`
+	Proof []string `json:"proof"` // Sibling hashes from leaf to root; empty for a single-voter tree
+}
+
+// Webhook is a subscription to poll lifecycle events on one chain.
+// PollAddress and Creator narrow it to one poll or one creator's polls;
+// with neither set it covers the whole chain.
+type Webhook struct {
+	ID          int       `json:"id"`
+	ChainID     int64     `json:"chain_id"`
+	URL         string    `json:"url"`
+	Secret      string    `json:"-"` // HMAC key; only returned when the webhook is created
+	EventTypes  []string  `json:"event_types"`
+	PollAddress *Address  `json:"poll_address,omitempty"`
+	Creator     *Address  `json:"creator,omitempty"`
+	Active      bool      `json:"active"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// WebhookDelivery is one entry of the delivery log
+type WebhookDelivery struct {
+	ID             int64           `json:"id"`
+	ChainID        int64           `json:"chain_id"`
+	WebhookID      int             `json:"webhook_id"`
+	EventType      string          `json:"event_type"`
+	PollAddress    Address         `json:"poll_address"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         string          `json:"status"` // pending, delivered or failed
+	Attempts       int             `json:"attempts"`
+	NextAttemptAt  time.Time       `json:"next_attempt_at"`
+	LastStatusCode *int            `json:"last_status_code,omitempty"`
+	LastError      *string         `json:"last_error,omitempty"`
+	ReplayOf       *int64          `json:"replay_of,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+
+	// Target of a claimed delivery; not part of the log
+	URL    string `json:"-"`
+	Secret string `json:"-"`
+}
+
+// IndexerStatus is the heartbeat written by a running listener
+type IndexerStatus struct {
+	ListenerID   string     `json:"listener_id"` // <chain ID>:<factory address>
+	ChainID      int64      `json:"chain_id"`
+	LastBlock    int64      `json:"last_block"`
+	HeadBlock    int64      `json:"head_block"`
+	HeadTime     *time.Time `json:"head_time,omitempty"` // Timestamp of HeadBlock
+	Subscribed   bool       `json:"subscribed"`
+	Paused       bool       `json:"paused"` // Paused as last seen by the listener
+	UpdatedAt    time.Time  `json:"updated_at"`
+	HeartbeatAge float64    `json:"heartbeat_age_seconds"` // Computed by the database at read time
+}
+
+// Lag returns how many blocks the listener is behind the chain head
+func (s *IndexerStatus) Lag() int64 {
+	if s.HeadBlock <= s.LastBlock {
+		return 0
+	}
+	return s.HeadBlock - s.LastBlock
+}
+
+// IndexerControl is the per-chain operator switch the indexer polls
+type IndexerControl struct {
+	Paused    bool      `json:"paused"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// IndexerCommand is a reindex request queued for the indexer
+type IndexerCommand struct {
+	ID          int64      `json:"id"`
+	ChainID     int64      `json:"chain_id"`
+	Factory     *Address   `json:"factory,omitempty"` // Listener that runs it; nil for any on the chain
+	Kind        string     `json:"kind"`              // reindex_range or reindex_poll
+	FromBlock   int64      `json:"from_block"`
+	ToBlock     int64      `json:"to_block"`
+	PollAddress *Address   `json:"poll_address,omitempty"`
+	Status      string     `json:"status"` // pending, running, done or failed
+	Error       *string    `json:"error,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	FinishedAt  *time.Time `json:"finished_at,omitempty"`
+}
+
+// FailedEvent is an indexed event whose handler returned an error
+type FailedEvent struct {
+	ID              int64      `json:"id"`
+	ContractAddress Address    `json:"contract_address"`
+	EventName       string     `json:"event_name"`
+	BlockNumber     int64      `json:"block_number"`
+	TransactionHash string     `json:"transaction_hash"`
+	LogIndex        int        `json:"log_index"`
+	Error           string     `json:"error"`
+	Attempts        int        `json:"attempts"`
+	FirstFailedAt   time.Time  `json:"first_failed_at"`
+	LastFailedAt    time.Time  `json:"last_failed_at"`
+	ResolvedAt      *time.Time `json:"resolved_at,omitempty"`
+}
+
+// PlatformStats are the aggregates of one chain served by /api/stats.
+// They are read from materialized views the indexer refreshes, so they
+// trail the indexed data by up to one refresh interval.
+type PlatformStats struct {
+	PollsByState map[string]int `json:"polls_by_state"`
+	TotalPolls   int            `json:"total_polls"`
+	TotalCommits int64          `json:"total_commits"`
+	TotalReveals int64          `json:"total_reveals"`
+	// AvgRevealRate is the mean of per-poll reveal rates, 0-1, over polls
+	// with at least one commit
+	AvgRevealRate *float64 `json:"avg_reveal_rate"`
+	// AvgCloseDelay is the mean time from closes_at to the PollClosed event
+	AvgCloseDelay *float64        `json:"avg_close_delay_seconds"`
+	TopCreators   []*CreatorStats `json:"top_creators"`
+	TopVoters     []*VoterStats   `json:"top_voters"`
+	RefreshedAt   time.Time       `json:"refreshed_at"`
+}
+
+// CreatorStats is the activity of one poll creator
+type CreatorStats struct {
+	Address      Address   `json:"address"`
+	Polls        int       `json:"polls"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
+
+// VoterStats is the activity of one voter
+type VoterStats struct {
+	Address      Address   `json:"address"`
+	Commits      int       `json:"commits"`
+	Reveals      int       `json:"reveals"`
+	LastActiveAt time.Time `json:"last_active_at"`
+}
@@ -0,0 +1,129 @@
+package database
+
+import (
+	"context"
+	"fmt"
+)
+
+// platformViews are the materialized views behind PlatformStats
+var platformViews = []string{
+	"platform_poll_states",
+	"platform_totals",
+	"platform_creators",
+	"platform_voters",
+}
+
+// RefreshPlatformStats recomputes the platform-wide aggregates. Views are
+// refreshed concurrently so API reads are not blocked meanwhile.
+func (db *DB) RefreshPlatformStats(ctx context.Context) error {
+	for _, view := range platformViews {
+		if _, err := db.Pool.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+			return fmt.Errorf("failed to refresh %s: %w", view, err)
+		}
+	}
+
+	_, err := db.Pool.Exec(ctx, `UPDATE platform_stats_refresh SET refreshed_at = NOW()`)
+	if err != nil {
+		return fmt.Errorf("failed to record stats refresh: %w", err)
+	}
+
+	return nil
+}
+
+// GetPlatformStats reads the chain's aggregates with the top creators and
+// voters. A chain with no indexed polls reads as zero totals.
+func (db *DB) GetPlatformStats(ctx context.Context, top int) (*PlatformStats, error) {
+	stats := &PlatformStats{
+		PollsByState: map[string]int{},
+		TopCreators:  []*CreatorStats{},
+		TopVoters:    []*VoterStats{},
+	}
+
+	query := `
+		SELECT COALESCE(t.total_commits, 0), COALESCE(t.total_reveals, 0), t.avg_reveal_rate,
+			t.avg_close_delay_seconds, r.refreshed_at
+		FROM platform_stats_refresh r
+		LEFT JOIN platform_totals t ON t.chain_id = $1
+	`
+	err := db.Pool.QueryRow(ctx, query, ChainFrom(ctx)).Scan(
+		&stats.TotalCommits, &stats.TotalReveals, &stats.AvgRevealRate,
+		&stats.AvgCloseDelay, &stats.RefreshedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform totals: %w", err)
+	}
+
+	rows, err := db.Pool.Query(ctx, `SELECT state, polls FROM platform_poll_states WHERE chain_id = $1`, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls by state: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var state string
+		var polls int
+		if err := rows.Scan(&state, &polls); err != nil {
+			return nil, fmt.Errorf("failed to scan poll state count: %w", err)
+		}
+		stats.PollsByState[state] = polls
+		stats.TotalPolls += polls
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	creatorsQuery := `
+		SELECT address, polls, last_active_at
+		FROM platform_creators
+		WHERE chain_id = $2
+		ORDER BY polls DESC, address ASC
+		LIMIT $1
+	`
+
+	rows, err = db.Pool.Query(ctx, creatorsQuery, top, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top creators: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		creator := &CreatorStats{}
+		if err := rows.Scan(&creator.Address, &creator.Polls, &creator.LastActiveAt); err != nil {
+			return nil, fmt.Errorf("failed to scan creator: %w", err)
+		}
+		stats.TopCreators = append(stats.TopCreators, creator)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	votersQuery := `
+		SELECT address, commits, reveals, last_active_at
+		FROM platform_voters
+		WHERE chain_id = $2
+		ORDER BY commits DESC, address ASC
+		LIMIT $1
+	`
+
+	rows, err = db.Pool.Query(ctx, votersQuery, top, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top voters: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		voter := &VoterStats{}
+		if err := rows.Scan(&voter.Address, &voter.Commits, &voter.Reveals, &voter.LastActiveAt); err != nil {
+			return nil, fmt.Errorf("failed to scan voter: %w", err)
+		}
+		stats.TopVoters = append(stats.TopVoters, voter)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return stats, nil
+}
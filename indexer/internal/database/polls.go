@@ -3,17 +3,31 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
-// CreatePoll inserts a new poll into the database
+// CreatePoll inserts a new poll into the database. Reindexing a poll
+// refreshes the fields read at creation and keeps its current state.
 func (db *DB) CreatePoll(ctx context.Context, poll *Poll) error {
 	query := `
 		INSERT INTO polls (
 			contract_address, question, options, duration, voter_merkle_root,
-			created_at, closes_at, state, creator, block_number, transaction_hash
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			created_at, closes_at, state, creator, block_number, transaction_hash,
+			chain_id, factory
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		ON CONFLICT (chain_id, contract_address) DO UPDATE
+		SET factory = COALESCE(EXCLUDED.factory, polls.factory),
+			question = EXCLUDED.question,
+			options = EXCLUDED.options,
+			duration = EXCLUDED.duration,
+			voter_merkle_root = EXCLUDED.voter_merkle_root,
+			created_at = EXCLUDED.created_at,
+			closes_at = EXCLUDED.closes_at,
+			creator = EXCLUDED.creator,
+			block_number = EXCLUDED.block_number,
+			transaction_hash = EXCLUDED.transaction_hash
 		RETURNING id, created_timestamp
 	`
 
@@ -22,6 +36,7 @@ func (db *DB) CreatePoll(ctx context.Context, poll *Poll) error {
 		poll.ContractAddress, poll.Question, poll.Options, poll.Duration,
 		poll.VoterMerkleRoot, poll.CreatedAt, poll.ClosesAt, poll.State,
 		poll.Creator, poll.BlockNumber, poll.TransactionHash,
+		ChainFrom(ctx), poll.Factory,
 	).Scan(&poll.ID, &poll.CreatedTimestamp)
 
 	if err != nil {
@@ -32,21 +47,23 @@ func (db *DB) CreatePoll(ctx context.Context, poll *Poll) error {
 }
 
 // GetPollByAddress retrieves a poll by its contract address
-func (db *DB) GetPollByAddress(ctx context.Context, address string) (*Poll, error) {
+func (db *DB) GetPollByAddress(ctx context.Context, address Address) (*Poll, error) {
 	query := `
-		SELECT id, contract_address, question, options, duration, voter_merkle_root,
-			created_at, closes_at, state, creator, block_number, transaction_hash,
-			created_timestamp
+		SELECT id, chain_id, factory, contract_address, question, options, duration,
+			voter_merkle_root, created_at, closes_at, state, creator, block_number,
+			transaction_hash, created_timestamp,
+			COALESCE((SELECT tags FROM poll_annotations a
+				WHERE a.chain_id = polls.chain_id AND a.poll_address = polls.contract_address), '{}')
 		FROM polls
-		WHERE contract_address = $1
+		WHERE chain_id = $2 AND contract_address = $1
 	`
 
 	poll := &Poll{}
-	err := db.Pool.QueryRow(ctx, query, address).Scan(
-		&poll.ID, &poll.ContractAddress, &poll.Question, &poll.Options,
+	err := db.Pool.QueryRow(ctx, query, address, ChainFrom(ctx)).Scan(
+		&poll.ID, &poll.ChainID, &poll.Factory, &poll.ContractAddress, &poll.Question, &poll.Options,
 		&poll.Duration, &poll.VoterMerkleRoot, &poll.CreatedAt, &poll.ClosesAt,
 		&poll.State, &poll.Creator, &poll.BlockNumber, &poll.TransactionHash,
-		&poll.CreatedTimestamp,
+		&poll.CreatedTimestamp, &poll.Tags,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -59,33 +76,113 @@ func (db *DB) GetPollByAddress(ctx context.Context, address string) (*Poll, erro
 	return poll, nil
 }
 
-// ListPolls retrieves all polls with optional state filter
-func (db *DB) ListPolls(ctx context.Context, state string, limit, offset int) ([]*Poll, error) {
-	var query string
-	var args []interface{}
+// GetPollVersion returns the version of an indexed poll, or nil if it has
+// not been indexed. It reads only the poll's event index, so it is cheap
+// enough to run before deciding whether a response needs building.
+func (db *DB) GetPollVersion(ctx context.Context, address Address) (*PollVersion, error) {
+	query := `
+		SELECT p.state, p.closes_at,
+			GREATEST(p.block_number, MAX(e.block_number)),
+			GREATEST(p.created_timestamp, MAX(e.created_timestamp), a.updated_at, t.created_at),
+			a.updated_at, t.created_at
+		FROM polls p
+		LEFT JOIN events e ON e.chain_id = p.chain_id AND e.contract_address = p.contract_address
+		LEFT JOIN poll_annotations a ON a.chain_id = p.chain_id AND a.poll_address = p.contract_address
+		LEFT JOIN merkle_trees t ON t.root = p.voter_merkle_root
+		WHERE p.chain_id = $2 AND p.contract_address = $1
+		GROUP BY p.id, a.updated_at, t.created_at
+	`
 
-	if state != "" {
-		query = `
-			SELECT id, contract_address, question, options, duration, voter_merkle_root,
-				created_at, closes_at, state, creator, block_number, transaction_hash,
-				created_timestamp
-			FROM polls
-			WHERE state = $1
-			ORDER BY created_at DESC
-			LIMIT $2 OFFSET $3
-		`
-		args = []interface{}{state, limit, offset}
-	} else {
-		query = `
-			SELECT id, contract_address, question, options, duration, voter_merkle_root,
-				created_at, closes_at, state, creator, block_number, transaction_hash,
-				created_timestamp
-			FROM polls
-			ORDER BY created_at DESC
-			LIMIT $1 OFFSET $2
-		`
-		args = []interface{}{limit, offset}
+	version := &PollVersion{}
+	err := db.Pool.QueryRow(ctx, query, address, ChainFrom(ctx)).Scan(
+		&version.State, &version.ClosesAt, &version.LastBlock,
+		&version.ModifiedAt, &version.AnnotatedAt, &version.TreeAt,
+	)
+
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get poll version: %w", err)
+	}
+
+	return version, nil
+}
+
+// GetPollsByAddresses retrieves the polls with the given contract addresses.
+// Addresses with no indexed poll are absent from the returned map.
+func (db *DB) GetPollsByAddresses(ctx context.Context, addresses []Address) (map[Address]*Poll, error) {
+	query := `
+		SELECT id, chain_id, factory, contract_address, question, options, duration,
+			voter_merkle_root, created_at, closes_at, state, creator, block_number,
+			transaction_hash, created_timestamp,
+			COALESCE((SELECT tags FROM poll_annotations a
+				WHERE a.chain_id = polls.chain_id AND a.poll_address = polls.contract_address), '{}')
+		FROM polls
+		WHERE chain_id = $2 AND contract_address = ANY($1)
+	`
+
+	rows, err := db.Pool.Query(ctx, query, addressStrings(addresses), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polls: %w", err)
 	}
+	defer rows.Close()
+
+	polls := make(map[Address]*Poll, len(addresses))
+	for rows.Next() {
+		poll := &Poll{}
+		err := rows.Scan(
+			&poll.ID, &poll.ChainID, &poll.Factory, &poll.ContractAddress, &poll.Question, &poll.Options,
+			&poll.Duration, &poll.VoterMerkleRoot, &poll.CreatedAt, &poll.ClosesAt,
+			&poll.State, &poll.Creator, &poll.BlockNumber, &poll.TransactionHash,
+			&poll.CreatedTimestamp, &poll.Tags,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan poll: %w", err)
+		}
+		polls[poll.ContractAddress] = poll
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return polls, nil
+}
+
+// PollFilter narrows ListPolls. Empty fields match every poll; filtering
+// on the derived Status judges closing times against ChainTime.
+type PollFilter struct {
+	State     string
+	Status    string
+	ChainTime time.Time
+	Tag       string // Annotation tag the poll must carry
+}
+
+// ListPolls retrieves all polls with optional state, status and tag filters
+func (db *DB) ListPolls(ctx context.Context, filter PollFilter, limit, offset int) ([]*Poll, error) {
+	query := `
+		SELECT id, chain_id, factory, contract_address, question, options, duration,
+			voter_merkle_root, created_at, closes_at, state, creator, block_number,
+			transaction_hash, created_timestamp,
+			COALESCE((SELECT tags FROM poll_annotations a
+				WHERE a.chain_id = polls.chain_id AND a.poll_address = polls.contract_address), '{}')
+		FROM polls
+		WHERE chain_id = $7
+			AND ($1 = '' OR state = $1)
+			AND ($2 = '' OR CASE
+				WHEN state = 'active' AND closes_at <= $3 THEN 'overdue'
+				ELSE state
+			END = $2)
+			AND ($4 = '' OR EXISTS (
+				SELECT 1 FROM poll_annotations a
+				WHERE a.chain_id = polls.chain_id AND a.poll_address = polls.contract_address
+					AND a.tags @> ARRAY[$4::text]
+			))
+		ORDER BY created_at DESC
+		LIMIT $5 OFFSET $6
+	`
+	args := []interface{}{filter.State, filter.Status, filter.ChainTime, filter.Tag, limit, offset, ChainFrom(ctx)}
 
 	rows, err := db.Pool.Query(ctx, query, args...)
 	if err != nil {
@@ -93,14 +190,14 @@ func (db *DB) ListPolls(ctx context.Context, state string, limit, offset int) ([
 	}
 	defer rows.Close()
 
-	var polls []*Poll
+	polls := []*Poll{}
 	for rows.Next() {
 		poll := &Poll{}
 		err := rows.Scan(
-			&poll.ID, &poll.ContractAddress, &poll.Question, &poll.Options,
+			&poll.ID, &poll.ChainID, &poll.Factory, &poll.ContractAddress, &poll.Question, &poll.Options,
 			&poll.Duration, &poll.VoterMerkleRoot, &poll.CreatedAt, &poll.ClosesAt,
 			&poll.State, &poll.Creator, &poll.BlockNumber, &poll.TransactionHash,
-			&poll.CreatedTimestamp,
+			&poll.CreatedTimestamp, &poll.Tags,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan poll: %w", err)
@@ -115,16 +212,85 @@ func (db *DB) ListPolls(ctx context.Context, state string, limit, offset int) ([
 	return polls, nil
 }
 
-// UpdatePollState updates the state of a poll
-func (db *DB) UpdatePollState(ctx context.Context, address, state string) error {
-	query := `UPDATE polls SET state = $1 WHERE contract_address = $2`
+// ListOverduePolls retrieves active polls whose closing time is at least
+// minOverdue before chainTime, longest overdue first
+func (db *DB) ListOverduePolls(ctx context.Context, chainTime time.Time, minOverdue time.Duration, limit int) ([]*Poll, error) {
+	query := `
+		SELECT id, chain_id, factory, contract_address, question, options, duration,
+			voter_merkle_root, created_at, closes_at, state, creator, block_number,
+			transaction_hash, created_timestamp,
+			COALESCE((SELECT tags FROM poll_annotations a
+				WHERE a.chain_id = polls.chain_id AND a.poll_address = polls.contract_address), '{}')
+		FROM polls
+		WHERE chain_id = $3 AND state = 'active' AND closes_at <= $1
+		ORDER BY closes_at ASC
+		LIMIT $2
+	`
 
-	result, err := db.Pool.Exec(ctx, query, state, address)
+	rows, err := db.Pool.Query(ctx, query, chainTime.Add(-minOverdue), limit, ChainFrom(ctx))
 	if err != nil {
+		return nil, fmt.Errorf("failed to list overdue polls: %w", err)
+	}
+	defer rows.Close()
+
+	polls := []*Poll{}
+	for rows.Next() {
+		poll := &Poll{}
+		err := rows.Scan(
+			&poll.ID, &poll.ChainID, &poll.Factory, &poll.ContractAddress, &poll.Question, &poll.Options,
+			&poll.Duration, &poll.VoterMerkleRoot, &poll.CreatedAt, &poll.ClosesAt,
+			&poll.State, &poll.Creator, &poll.BlockNumber, &poll.TransactionHash,
+			&poll.CreatedTimestamp, &poll.Tags,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan poll: %w", err)
+		}
+		polls = append(polls, poll)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return polls, nil
+}
+
+// SetPollClosedAt records when a poll was closed on chain
+func (db *DB) SetPollClosedAt(ctx context.Context, address Address, closedAt time.Time) error {
+	query := `UPDATE polls SET closed_at = $2 WHERE chain_id = $3 AND contract_address = $1`
+
+	if _, err := db.Pool.Exec(ctx, query, address, closedAt, ChainFrom(ctx)); err != nil {
+		return fmt.Errorf("failed to set poll close time: %w", err)
+	}
+
+	return nil
+}
+
+// UpdatePollState updates the state of a poll. States only move forward
+// (active, closed, tallied), so reindexing an earlier event leaves a poll
+// in its later state.
+func (db *DB) UpdatePollState(ctx context.Context, address Address, state string) error {
+	query := `
+		WITH target AS (
+			SELECT id,
+				array_position(ARRAY['active', 'closed', 'tallied'], state) AS current_rank,
+				array_position(ARRAY['active', 'closed', 'tallied'], $1::text) AS new_rank
+			FROM polls
+			WHERE chain_id = $3 AND contract_address = $2
+		), updated AS (
+			UPDATE polls SET state = $1
+			FROM target
+			WHERE polls.id = target.id AND target.current_rank <= target.new_rank
+		)
+		SELECT COUNT(*) FROM target
+	`
+
+	var found int
+	if err := db.Pool.QueryRow(ctx, query, state, address, ChainFrom(ctx)).Scan(&found); err != nil {
 		return fmt.Errorf("failed to update poll state: %w", err)
 	}
 
-	if result.RowsAffected() == 0 {
+	if found == 0 {
 		return fmt.Errorf("poll not found: %s", address)
 	}
 
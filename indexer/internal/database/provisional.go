@@ -0,0 +1,91 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// RecordProvisionalReveal counts a revealed vote towards the poll's
+// provisional tally. A voter is only ever counted once per poll, so
+// reprocessing the same block range leaves the tally unchanged.
+// Returns whether the reveal was newly counted.
+func (db *DB) RecordProvisionalReveal(ctx context.Context, pollAddress, voter Address, choice int, blockNumber int64) (bool, error) {
+	query := `
+		WITH counted AS (
+			INSERT INTO provisional_reveals (chain_id, poll_address, voter, choice, block_number)
+			VALUES ($5, $1, $2, $3, $4)
+			ON CONFLICT (chain_id, poll_address, voter) DO NOTHING
+			RETURNING chain_id, poll_address, choice
+		)
+		INSERT INTO provisional_tallies (chain_id, poll_address, choice, votes, last_block)
+		SELECT chain_id, poll_address, choice, 1, $4 FROM counted
+		ON CONFLICT (chain_id, poll_address, choice) DO UPDATE
+		SET votes = provisional_tallies.votes + 1,
+			last_block = GREATEST(provisional_tallies.last_block, EXCLUDED.last_block),
+			updated_at = NOW()
+	`
+
+	tag, err := db.Pool.Exec(ctx, query, pollAddress, voter, choice, blockNumber, ChainFrom(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to record provisional reveal: %w", err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetProvisionalTally returns the running count of revealed votes for a poll.
+// A poll with no counted reveals returns an empty tally.
+func (db *DB) GetProvisionalTally(ctx context.Context, pollAddress Address) (*ProvisionalTally, error) {
+	query := `
+		SELECT choice, votes, last_block, updated_at
+		FROM provisional_tallies
+		WHERE poll_address = $1 AND chain_id = $2
+	`
+
+	rows, err := db.Pool.Query(ctx, query, pollAddress, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get provisional tally: %w", err)
+	}
+	defer rows.Close()
+
+	tally := &ProvisionalTally{
+		PollAddress: pollAddress,
+		Counts:      make(map[int]int),
+	}
+	for rows.Next() {
+		var choice, votes int
+		var lastBlock int64
+		var updatedAt time.Time
+		if err := rows.Scan(&choice, &votes, &lastBlock, &updatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan provisional tally: %w", err)
+		}
+
+		tally.Counts[choice] = votes
+		tally.RevealedVotes += votes
+		if lastBlock > tally.LastBlock {
+			tally.LastBlock = lastBlock
+		}
+		if tally.UpdatedAt == nil || updatedAt.After(*tally.UpdatedAt) {
+			tally.UpdatedAt = &updatedAt
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return tally, nil
+}
+
+// SetProvisionalMatch records whether the provisional tally agreed with the
+// on-chain result
+func (db *DB) SetProvisionalMatch(ctx context.Context, pollAddress Address, match bool) error {
+	query := `UPDATE results SET provisional_match = $2 WHERE poll_address = $1 AND chain_id = $3`
+
+	if _, err := db.Pool.Exec(ctx, query, pollAddress, match, ChainFrom(ctx)); err != nil {
+		return fmt.Errorf("failed to set provisional match: %w", err)
+	}
+
+	return nil
+}
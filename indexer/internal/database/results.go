@@ -11,9 +11,9 @@ import (
 func (db *DB) CreateResult(ctx context.Context, result *Result) error {
 	query := `
 		INSERT INTO results (
-			poll_address, vote_counts, total_votes, tallied_at, block_number, transaction_hash
-		) VALUES ($1, $2, $3, $4, $5, $6)
-		ON CONFLICT (poll_address) DO UPDATE
+			poll_address, vote_counts, total_votes, tallied_at, block_number, transaction_hash, chain_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_id, poll_address) DO UPDATE
 		SET vote_counts = EXCLUDED.vote_counts,
 			total_votes = EXCLUDED.total_votes,
 			tallied_at = EXCLUDED.tallied_at,
@@ -25,7 +25,7 @@ func (db *DB) CreateResult(ctx context.Context, result *Result) error {
 	err := db.Pool.QueryRow(
 		ctx, query,
 		result.PollAddress, result.VoteCounts, result.TotalVotes,
-		result.TalliedAt, result.BlockNumber, result.TransactionHash,
+		result.TalliedAt, result.BlockNumber, result.TransactionHash, ChainFrom(ctx),
 	).Scan(&result.ID, &result.CreatedTimestamp)
 
 	if err != nil {
@@ -36,19 +36,19 @@ func (db *DB) CreateResult(ctx context.Context, result *Result) error {
 }
 
 // GetResultByPoll retrieves the results for a specific poll
-func (db *DB) GetResultByPoll(ctx context.Context, pollAddress string) (*Result, error) {
+func (db *DB) GetResultByPoll(ctx context.Context, pollAddress Address) (*Result, error) {
 	query := `
 		SELECT id, poll_address, vote_counts, total_votes, tallied_at,
-			block_number, transaction_hash, created_timestamp
+			block_number, transaction_hash, provisional_match, created_timestamp
 		FROM results
-		WHERE poll_address = $1
+		WHERE poll_address = $1 AND chain_id = $2
 	`
 
 	result := &Result{}
-	err := db.Pool.QueryRow(ctx, query, pollAddress).Scan(
+	err := db.Pool.QueryRow(ctx, query, pollAddress, ChainFrom(ctx)).Scan(
 		&result.ID, &result.PollAddress, &result.VoteCounts, &result.TotalVotes,
 		&result.TalliedAt, &result.BlockNumber, &result.TransactionHash,
-		&result.CreatedTimestamp,
+		&result.ProvisionalMatch, &result.CreatedTimestamp,
 	)
 
 	if err == pgx.ErrNoRows {
@@ -60,3 +60,40 @@ func (db *DB) GetResultByPoll(ctx context.Context, pollAddress string) (*Result,
 
 	return result, nil
 }
+
+// GetResultsByPolls retrieves the results of several polls in one query.
+// Polls without results are absent from the returned map.
+func (db *DB) GetResultsByPolls(ctx context.Context, pollAddresses []Address) (map[Address]*Result, error) {
+	query := `
+		SELECT id, poll_address, vote_counts, total_votes, tallied_at,
+			block_number, transaction_hash, provisional_match, created_timestamp
+		FROM results
+		WHERE poll_address = ANY($1) AND chain_id = $2
+	`
+
+	rows, err := db.Pool.Query(ctx, query, addressStrings(pollAddresses), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get results: %w", err)
+	}
+	defer rows.Close()
+
+	results := make(map[Address]*Result, len(pollAddresses))
+	for rows.Next() {
+		result := &Result{}
+		err := rows.Scan(
+			&result.ID, &result.PollAddress, &result.VoteCounts, &result.TotalVotes,
+			&result.TalliedAt, &result.BlockNumber, &result.TransactionHash,
+			&result.ProvisionalMatch, &result.CreatedTimestamp,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan result: %w", err)
+		}
+		results[result.PollAddress] = result
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return results, nil
+}
@@ -0,0 +1,83 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UpsertIndexerStatus writes a listener heartbeat
+func (db *DB) UpsertIndexerStatus(ctx context.Context, status *IndexerStatus) error {
+	query := `
+		INSERT INTO indexer_status (listener_id, chain_id, last_block, head_block, head_time, subscribed, paused, updated_at)
+		VALUES ($1, $7, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (listener_id) DO UPDATE
+		SET last_block = EXCLUDED.last_block,
+			head_block = EXCLUDED.head_block,
+			head_time = COALESCE(EXCLUDED.head_time, indexer_status.head_time),
+			subscribed = EXCLUDED.subscribed,
+			paused = EXCLUDED.paused,
+			updated_at = EXCLUDED.updated_at
+		RETURNING updated_at
+	`
+
+	err := db.Pool.QueryRow(
+		ctx, query,
+		status.ListenerID, status.LastBlock, status.HeadBlock, status.HeadTime, status.Subscribed, status.Paused,
+		ChainFrom(ctx),
+	).Scan(&status.UpdatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to update indexer status: %w", err)
+	}
+
+	return nil
+}
+
+// ListIndexerStatuses retrieves the heartbeat of every listener on every chain
+func (db *DB) ListIndexerStatuses(ctx context.Context) ([]*IndexerStatus, error) {
+	query := `
+		SELECT listener_id, chain_id, last_block, head_block, head_time, subscribed, paused, updated_at,
+			EXTRACT(EPOCH FROM (NOW() - updated_at))::float8
+		FROM indexer_status
+		ORDER BY chain_id, listener_id
+	`
+
+	rows, err := db.Pool.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list indexer status: %w", err)
+	}
+	defer rows.Close()
+
+	statuses := []*IndexerStatus{}
+	for rows.Next() {
+		status := &IndexerStatus{}
+		err := rows.Scan(
+			&status.ListenerID, &status.ChainID, &status.LastBlock, &status.HeadBlock, &status.HeadTime,
+			&status.Subscribed, &status.Paused, &status.UpdatedAt, &status.HeartbeatAge,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan indexer status: %w", err)
+		}
+		statuses = append(statuses, status)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return statuses, nil
+}
+
+// GetChainTime returns the latest chain head timestamp reported by any
+// listener on the chain, or nil before one has been reported
+func (db *DB) GetChainTime(ctx context.Context) (*time.Time, error) {
+	var headTime *time.Time
+	query := `SELECT MAX(head_time) FROM indexer_status WHERE chain_id = $1`
+	err := db.Pool.QueryRow(ctx, query, ChainFrom(ctx)).Scan(&headTime)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get chain time: %w", err)
+	}
+
+	return headTime, nil
+}
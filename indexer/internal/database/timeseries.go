@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrTooManyBuckets is returned when a timeseries would exceed MaxTimeseriesBuckets
+var ErrTooManyBuckets = errors.New("too many buckets")
+
+// MaxTimeseriesBuckets bounds the number of buckets a timeseries may return
+const MaxTimeseriesBuckets = 10000
+
+// Timeseries bucket sizes
+const (
+	BucketMinute = "1m"
+	BucketHour   = "1h"
+	BucketBlock  = "block"
+)
+
+// TimeseriesBucket holds commit and reveal counts for one bucket.
+// Time buckets set Start; block buckets set Block.
+type TimeseriesBucket struct {
+	Start             *time.Time `json:"start,omitempty"`
+	Block             *int64     `json:"block,omitempty"`
+	Commits           int        `json:"commits"`
+	Reveals           int        `json:"reveals"`
+	CumulativeCommits int        `json:"cumulative_commits"`
+	CumulativeReveals int        `json:"cumulative_reveals"`
+}
+
+// timeBuckets maps a bucket size to its date_trunc field and series step
+var timeBuckets = map[string][2]string{
+	BucketMinute: {"minute", "1 minute"},
+	BucketHour:   {"hour", "1 hour"},
+}
+
+// GetVoteTimeseries counts commits and reveals for a poll per bucket, from
+// the first to the last vote activity. Buckets without activity are
+// returned with zero counts.
+func (db *DB) GetVoteTimeseries(ctx context.Context, pollAddress Address, bucket string) ([]*TimeseriesBucket, error) {
+	if bucket == BucketBlock {
+		return db.getBlockTimeseries(ctx, pollAddress)
+	}
+
+	trunc, ok := timeBuckets[bucket]
+	if !ok {
+		return nil, fmt.Errorf("unknown bucket %q", bucket)
+	}
+
+	query := `
+		WITH commits AS (
+			SELECT date_trunc($2, committed_at) AS bucket, COUNT(*) AS n
+			FROM votes
+			WHERE chain_id = $5 AND poll_address = $1
+			GROUP BY 1
+		), reveals AS (
+			SELECT date_trunc($2, revealed_at) AS bucket, COUNT(*) AS n
+			FROM votes
+			WHERE chain_id = $5 AND poll_address = $1 AND revealed_at IS NOT NULL
+			GROUP BY 1
+		), bounds AS (
+			SELECT MIN(bucket) AS lo, MAX(bucket) AS hi
+			FROM (SELECT bucket FROM commits UNION ALL SELECT bucket FROM reveals) activity
+		), buckets AS (
+			SELECT generate_series(lo, hi, $3::interval) AS bucket
+			FROM bounds
+			WHERE lo IS NOT NULL
+		)
+		SELECT b.bucket,
+			COALESCE(c.n, 0), COALESCE(r.n, 0),
+			SUM(COALESCE(c.n, 0)) OVER (ORDER BY b.bucket),
+			SUM(COALESCE(r.n, 0)) OVER (ORDER BY b.bucket)
+		FROM buckets b
+		LEFT JOIN commits c ON c.bucket = b.bucket
+		LEFT JOIN reveals r ON r.bucket = b.bucket
+		ORDER BY b.bucket
+		LIMIT $4
+	`
+
+	rows, err := db.Pool.Query(ctx, query, pollAddress, trunc[0], trunc[1], MaxTimeseriesBuckets+1, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote timeseries: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []*TimeseriesBucket{}
+	for rows.Next() {
+		b := &TimeseriesBucket{}
+		var start time.Time
+		if err := rows.Scan(&start, &b.Commits, &b.Reveals, &b.CumulativeCommits, &b.CumulativeReveals); err != nil {
+			return nil, fmt.Errorf("failed to scan timeseries bucket: %w", err)
+		}
+		b.Start = &start
+		buckets = append(buckets, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	if len(buckets) > MaxTimeseriesBuckets {
+		return nil, ErrTooManyBuckets
+	}
+
+	return buckets, nil
+}
+
+func (db *DB) getBlockTimeseries(ctx context.Context, pollAddress Address) ([]*TimeseriesBucket, error) {
+	query := `
+		WITH commits AS (
+			SELECT block_number AS bucket, COUNT(*) AS n
+			FROM votes
+			WHERE chain_id = $3 AND poll_address = $1
+			GROUP BY 1
+		), reveals AS (
+			SELECT revealed_block AS bucket, COUNT(*) AS n
+			FROM votes
+			WHERE chain_id = $3 AND poll_address = $1 AND revealed_block IS NOT NULL
+			GROUP BY 1
+		), bounds AS (
+			SELECT MIN(bucket) AS lo, MAX(bucket) AS hi
+			FROM (SELECT bucket FROM commits UNION ALL SELECT bucket FROM reveals) activity
+		), buckets AS (
+			SELECT generate_series(lo, hi) AS bucket
+			FROM bounds
+			WHERE lo IS NOT NULL
+		)
+		SELECT b.bucket,
+			COALESCE(c.n, 0), COALESCE(r.n, 0),
+			SUM(COALESCE(c.n, 0)) OVER (ORDER BY b.bucket),
+			SUM(COALESCE(r.n, 0)) OVER (ORDER BY b.bucket)
+		FROM buckets b
+		LEFT JOIN commits c ON c.bucket = b.bucket
+		LEFT JOIN reveals r ON r.bucket = b.bucket
+		ORDER BY b.bucket
+		LIMIT $2
+	`
+
+	rows, err := db.Pool.Query(ctx, query, pollAddress, MaxTimeseriesBuckets+1, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote timeseries: %w", err)
+	}
+	defer rows.Close()
+
+	buckets := []*TimeseriesBucket{}
+	for rows.Next() {
+		b := &TimeseriesBucket{}
+		var block int64
+		if err := rows.Scan(&block, &b.Commits, &b.Reveals, &b.CumulativeCommits, &b.CumulativeReveals); err != nil {
+			return nil, fmt.Errorf("failed to scan timeseries bucket: %w", err)
+		}
+		b.Block = &block
+		buckets = append(buckets, b)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	if len(buckets) > MaxTimeseriesBuckets {
+		return nil, ErrTooManyBuckets
+	}
+
+	return buckets, nil
+}
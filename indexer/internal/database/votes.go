@@ -3,23 +3,30 @@ package database
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 )
 
-// CreateVote inserts a new vote commitment into the database
+// CreateVote inserts a new vote commitment into the database. Reindexing
+// a commitment refreshes it without clearing a recorded reveal.
 func (db *DB) CreateVote(ctx context.Context, vote *Vote) error {
 	query := `
 		INSERT INTO votes (
-			poll_address, voter, commitment, committed_at, block_number, transaction_hash
-		) VALUES ($1, $2, $3, $4, $5, $6)
+			poll_address, voter, commitment, committed_at, block_number, transaction_hash, chain_id
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (chain_id, poll_address, voter) DO UPDATE
+		SET commitment = EXCLUDED.commitment,
+			committed_at = EXCLUDED.committed_at,
+			block_number = EXCLUDED.block_number,
+			transaction_hash = EXCLUDED.transaction_hash
 		RETURNING id, created_timestamp
 	`
 
 	err := db.Pool.QueryRow(
 		ctx, query,
 		vote.PollAddress, vote.Voter, vote.Commitment,
-		vote.CommittedAt, vote.BlockNumber, vote.TransactionHash,
+		vote.CommittedAt, vote.BlockNumber, vote.TransactionHash, ChainFrom(ctx),
 	).Scan(&vote.ID, &vote.CreatedTimestamp)
 
 	if err != nil {
@@ -30,14 +37,14 @@ func (db *DB) CreateVote(ctx context.Context, vote *Vote) error {
 }
 
 // RevealVote updates a vote with the revealed choice and nonce
-func (db *DB) RevealVote(ctx context.Context, pollAddress, voter string, choice int, nonce []byte) error {
+func (db *DB) RevealVote(ctx context.Context, pollAddress, voter Address, choice int, nonce []byte, revealedAt time.Time, revealedBlock int64) error {
 	query := `
 		UPDATE votes
-		SET choice = $3, nonce = $4, revealed = true, revealed_at = NOW()
-		WHERE poll_address = $1 AND voter = $2
+		SET choice = $3, nonce = $4, revealed = true, revealed_at = $5, revealed_block = $6
+		WHERE poll_address = $1 AND voter = $2 AND chain_id = $7
 	`
 
-	result, err := db.Pool.Exec(ctx, query, pollAddress, voter, choice, nonce)
+	result, err := db.Pool.Exec(ctx, query, pollAddress, voter, choice, nonce, revealedAt, revealedBlock, ChainFrom(ctx))
 	if err != nil {
 		return fmt.Errorf("failed to reveal vote: %w", err)
 	}
@@ -50,19 +57,19 @@ func (db *DB) RevealVote(ctx context.Context, pollAddress, voter string, choice
 }
 
 // GetVote retrieves a vote by poll address and voter
-func (db *DB) GetVote(ctx context.Context, pollAddress, voter string) (*Vote, error) {
+func (db *DB) GetVote(ctx context.Context, pollAddress, voter Address) (*Vote, error) {
 	query := `
 		SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
-			committed_at, revealed_at, block_number, transaction_hash, created_timestamp
+			committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
 		FROM votes
-		WHERE poll_address = $1 AND voter = $2
+		WHERE poll_address = $1 AND voter = $2 AND chain_id = $3
 	`
 
 	vote := &Vote{}
-	err := db.Pool.QueryRow(ctx, query, pollAddress, voter).Scan(
+	err := db.Pool.QueryRow(ctx, query, pollAddress, voter, ChainFrom(ctx)).Scan(
 		&vote.ID, &vote.PollAddress, &vote.Voter, &vote.Commitment,
 		&vote.Choice, &vote.Nonce, &vote.Revealed, &vote.CommittedAt,
-		&vote.RevealedAt, &vote.BlockNumber, &vote.TransactionHash,
+		&vote.RevealedAt, &vote.RevealedBlock, &vote.BlockNumber, &vote.TransactionHash,
 		&vote.CreatedTimestamp,
 	)
 
@@ -77,39 +84,39 @@ func (db *DB) GetVote(ctx context.Context, pollAddress, voter string) (*Vote, er
 }
 
 // ListVotesByPoll retrieves all votes for a specific poll
-func (db *DB) ListVotesByPoll(ctx context.Context, pollAddress string, revealedOnly bool) ([]*Vote, error) {
+func (db *DB) ListVotesByPoll(ctx context.Context, pollAddress Address, revealedOnly bool) ([]*Vote, error) {
 	var query string
 	if revealedOnly {
 		query = `
 			SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
-				committed_at, revealed_at, block_number, transaction_hash, created_timestamp
+				committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
 			FROM votes
-			WHERE poll_address = $1 AND revealed = true
+			WHERE poll_address = $1 AND chain_id = $2 AND revealed = true
 			ORDER BY committed_at ASC
 		`
 	} else {
 		query = `
 			SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
-				committed_at, revealed_at, block_number, transaction_hash, created_timestamp
+				committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
 			FROM votes
-			WHERE poll_address = $1
+			WHERE poll_address = $1 AND chain_id = $2
 			ORDER BY committed_at ASC
 		`
 	}
 
-	rows, err := db.Pool.Query(ctx, query, pollAddress)
+	rows, err := db.Pool.Query(ctx, query, pollAddress, ChainFrom(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed to list votes: %w", err)
 	}
 	defer rows.Close()
 
-	var votes []*Vote
+	votes := []*Vote{}
 	for rows.Next() {
 		vote := &Vote{}
 		err := rows.Scan(
 			&vote.ID, &vote.PollAddress, &vote.Voter, &vote.Commitment,
 			&vote.Choice, &vote.Nonce, &vote.Revealed, &vote.CommittedAt,
-			&vote.RevealedAt, &vote.BlockNumber, &vote.TransactionHash,
+			&vote.RevealedAt, &vote.RevealedBlock, &vote.BlockNumber, &vote.TransactionHash,
 			&vote.CreatedTimestamp,
 		)
 		if err != nil {
@@ -126,19 +133,236 @@ func (db *DB) ListVotesByPoll(ctx context.Context, pollAddress string, revealedO
 }
 
 // GetVoteCount returns the total number of votes for a poll
-func (db *DB) GetVoteCount(ctx context.Context, pollAddress string, revealedOnly bool) (int, error) {
+func (db *DB) GetVoteCount(ctx context.Context, pollAddress Address, revealedOnly bool) (int, error) {
 	var query string
 	if revealedOnly {
-		query = `SELECT COUNT(*) FROM votes WHERE poll_address = $1 AND revealed = true`
+		query = `SELECT COUNT(*) FROM votes WHERE poll_address = $1 AND chain_id = $2 AND revealed = true`
 	} else {
-		query = `SELECT COUNT(*) FROM votes WHERE poll_address = $1`
+		query = `SELECT COUNT(*) FROM votes WHERE poll_address = $1 AND chain_id = $2`
 	}
 
 	var count int
-	err := db.Pool.QueryRow(ctx, query, pollAddress).Scan(&count)
+	err := db.Pool.QueryRow(ctx, query, pollAddress, ChainFrom(ctx)).Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get vote count: %w", err)
 	}
 
 	return count, nil
 }
+
+// CountRevealedChoices returns the number of revealed votes per option index
+func (db *DB) CountRevealedChoices(ctx context.Context, pollAddress Address) (map[int]int, error) {
+	query := `
+		SELECT choice, COUNT(*)
+		FROM votes
+		WHERE poll_address = $1 AND chain_id = $2 AND revealed = true AND choice IS NOT NULL
+		GROUP BY choice
+	`
+
+	rows, err := db.Pool.Query(ctx, query, pollAddress, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to count revealed choices: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[int]int)
+	for rows.Next() {
+		var choice, count int
+		if err := rows.Scan(&choice, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan choice count: %w", err)
+		}
+		counts[choice] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// VoteCounts holds commit and reveal counts for a poll
+type VoteCounts struct {
+	Total    int `json:"total_votes"`
+	Revealed int `json:"revealed_votes"`
+}
+
+// GetVoteCountsByPolls returns vote counts for several polls in one query.
+// Polls without votes map to zero counts.
+func (db *DB) GetVoteCountsByPolls(ctx context.Context, pollAddresses []Address) (map[Address]VoteCounts, error) {
+	query := `
+		SELECT poll_address, COUNT(*), COUNT(*) FILTER (WHERE revealed)
+		FROM votes
+		WHERE poll_address = ANY($1) AND chain_id = $2
+		GROUP BY poll_address
+	`
+
+	rows, err := db.Pool.Query(ctx, query, addressStrings(pollAddresses), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vote counts: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[Address]VoteCounts, len(pollAddresses))
+	for _, address := range pollAddresses {
+		counts[address] = VoteCounts{}
+	}
+	for rows.Next() {
+		var address Address
+		var c VoteCounts
+		if err := rows.Scan(&address, &c.Total, &c.Revealed); err != nil {
+			return nil, fmt.Errorf("failed to scan vote counts: %w", err)
+		}
+		counts[address] = c
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return counts, nil
+}
+
+// ListVotesByPolls retrieves all votes for several polls in one query, grouped by poll
+func (db *DB) ListVotesByPolls(ctx context.Context, pollAddresses []Address) (map[Address][]*Vote, error) {
+	query := `
+		SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
+			committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
+		FROM votes
+		WHERE poll_address = ANY($1) AND chain_id = $2
+		ORDER BY committed_at ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, addressStrings(pollAddresses), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list votes: %w", err)
+	}
+
+	votes, err := scanVotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[Address][]*Vote, len(pollAddresses))
+	for _, vote := range votes {
+		grouped[vote.PollAddress] = append(grouped[vote.PollAddress], vote)
+	}
+	return grouped, nil
+}
+
+// ListVotesByVoters retrieves the voting history of several voters in one query, grouped by voter
+func (db *DB) ListVotesByVoters(ctx context.Context, voters []Address) (map[Address][]*Vote, error) {
+	query := `
+		SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
+			committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
+		FROM votes
+		WHERE voter = ANY($1) AND chain_id = $2
+		ORDER BY committed_at ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, addressStrings(voters), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list votes: %w", err)
+	}
+
+	votes, err := scanVotes(rows)
+	if err != nil {
+		return nil, err
+	}
+
+	grouped := make(map[Address][]*Vote, len(voters))
+	for _, vote := range votes {
+		grouped[vote.Voter] = append(grouped[vote.Voter], vote)
+	}
+	return grouped, nil
+}
+
+// ListVotesByTransaction retrieves the votes a transaction touched: those
+// committed in it, and those of the given voters on the given polls, which
+// callers take from the transaction's VoteRevealed events. pollAddresses
+// and voters are parallel slices.
+func (db *DB) ListVotesByTransaction(ctx context.Context, txHash string, pollAddresses, voters []Address) ([]*Vote, error) {
+	query := `
+		SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
+			committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
+		FROM votes
+		WHERE chain_id = $4 AND (transaction_hash = $1
+			OR (poll_address, voter) IN (SELECT * FROM unnest($2::varchar[], $3::varchar[])))
+		ORDER BY committed_at ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, txHash, addressStrings(pollAddresses), addressStrings(voters), ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list votes: %w", err)
+	}
+
+	return scanVotes(rows)
+}
+
+// StreamVotesByPoll calls fn for every vote of a poll in commit order
+// without loading them all into memory
+func (db *DB) StreamVotesByPoll(ctx context.Context, pollAddress Address, fn func(*Vote) error) error {
+	query := `
+		SELECT id, poll_address, voter, commitment, choice, nonce, revealed,
+			committed_at, revealed_at, revealed_block, block_number, transaction_hash, created_timestamp
+		FROM votes
+		WHERE poll_address = $1 AND chain_id = $2
+		ORDER BY committed_at ASC
+	`
+
+	rows, err := db.Pool.Query(ctx, query, pollAddress, ChainFrom(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to stream votes: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		vote, err := scanVote(rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(vote); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("rows error: %w", err)
+	}
+
+	return nil
+}
+
+// scanVotes reads every row of a votes query and closes rows
+func scanVotes(rows pgx.Rows) ([]*Vote, error) {
+	defer rows.Close()
+
+	votes := []*Vote{}
+	for rows.Next() {
+		vote, err := scanVote(rows)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return votes, nil
+}
+
+func scanVote(rows pgx.Rows) (*Vote, error) {
+	vote := &Vote{}
+	err := rows.Scan(
+		&vote.ID, &vote.PollAddress, &vote.Voter, &vote.Commitment,
+		&vote.Choice, &vote.Nonce, &vote.Revealed, &vote.CommittedAt,
+		&vote.RevealedAt, &vote.RevealedBlock, &vote.BlockNumber, &vote.TransactionHash,
+		&vote.CreatedTimestamp,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan vote: %w", err)
+	}
+	return vote, nil
+}
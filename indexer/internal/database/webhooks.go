@@ -0,0 +1,259 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// Delivery states in the webhook log
+const (
+	DeliveryPending   = "pending"
+	DeliveryDelivered = "delivered"
+	DeliveryFailed    = "failed"
+)
+
+const webhookColumns = `id, chain_id, url, secret, event_types, poll_address, creator, active, created_at`
+
+const deliveryColumns = `
+	d.id, d.chain_id, d.webhook_id, d.event_type, d.poll_address, d.payload, d.status,
+	d.attempts, d.next_attempt_at, d.last_status_code, d.last_error,
+	d.replay_of, d.created_at, d.delivered_at
+`
+
+// CreateWebhook inserts a new webhook subscription to events on the chain
+func (db *DB) CreateWebhook(ctx context.Context, hook *Webhook) error {
+	query := `
+		INSERT INTO webhooks (url, secret, event_types, poll_address, creator, chain_id)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, chain_id, active, created_at
+	`
+
+	err := db.Pool.QueryRow(
+		ctx, query,
+		hook.URL, hook.Secret, hook.EventTypes, hook.PollAddress, hook.Creator, ChainFrom(ctx),
+	).Scan(&hook.ID, &hook.ChainID, &hook.Active, &hook.CreatedAt)
+
+	if err != nil {
+		return fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+// GetWebhook retrieves a webhook by ID
+func (db *DB) GetWebhook(ctx context.Context, id int) (*Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE id = $1 AND chain_id = $2`
+
+	hook, err := scanWebhook(db.Pool.QueryRow(ctx, query, id, ChainFrom(ctx)))
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook: %w", err)
+	}
+
+	return hook, nil
+}
+
+// ListWebhooks retrieves all webhook subscriptions on the chain
+func (db *DB) ListWebhooks(ctx context.Context) ([]*Webhook, error) {
+	query := `SELECT ` + webhookColumns + ` FROM webhooks WHERE chain_id = $1 ORDER BY id`
+
+	rows, err := db.Pool.Query(ctx, query, ChainFrom(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhooks: %w", err)
+	}
+	defer rows.Close()
+
+	hooks := []*Webhook{}
+	for rows.Next() {
+		hook, err := scanWebhook(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook: %w", err)
+		}
+		hooks = append(hooks, hook)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return hooks, nil
+}
+
+// DeleteWebhook removes a webhook and its delivery log.
+// It reports false if no webhook with that ID exists.
+func (db *DB) DeleteWebhook(ctx context.Context, id int) (bool, error) {
+	result, err := db.Pool.Exec(ctx, `DELETE FROM webhooks WHERE id = $1 AND chain_id = $2`, id, ChainFrom(ctx))
+	if err != nil {
+		return false, fmt.Errorf("failed to delete webhook: %w", err)
+	}
+
+	return result.RowsAffected() > 0, nil
+}
+
+// EnqueueWebhookDeliveries adds a pending delivery for every active webhook
+// subscribed to the event for this poll. Reprocessing the same transition
+// enqueues nothing. It returns the number of deliveries added.
+func (db *DB) EnqueueWebhookDeliveries(ctx context.Context, eventType string, poll *Poll, payload []byte) (int, error) {
+	query := `
+		INSERT INTO webhook_deliveries (webhook_id, event_type, poll_address, payload, chain_id)
+		SELECT id, $1, $2, $4, chain_id
+		FROM webhooks
+		WHERE active AND chain_id = $5
+			AND (cardinality(event_types) = 0 OR $1 = ANY(event_types))
+			AND (poll_address IS NULL OR poll_address = $2)
+			AND (creator IS NULL OR creator = $3)
+		ON CONFLICT (webhook_id, event_type, poll_address) WHERE replay_of IS NULL
+		DO NOTHING
+	`
+
+	result, err := db.Pool.Exec(ctx, query, eventType, poll.ContractAddress, poll.Creator, payload, ChainFrom(ctx))
+	if err != nil {
+		return 0, fmt.Errorf("failed to enqueue webhook deliveries: %w", err)
+	}
+
+	return int(result.RowsAffected()), nil
+}
+
+// ClaimWebhookDeliveries returns up to limit due deliveries together with
+// their target URL and secret. Claimed rows are leased by pushing
+// next_attempt_at forward, so concurrent dispatchers do not send the same
+// delivery twice; RecordWebhookAttempt replaces the lease.
+func (db *DB) ClaimWebhookDeliveries(ctx context.Context, limit int, lease time.Duration) ([]*WebhookDelivery, error) {
+	query := `
+		WITH due AS (
+			SELECT id FROM webhook_deliveries
+			WHERE status = 'pending' AND next_attempt_at <= NOW()
+			ORDER BY next_attempt_at
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE webhook_deliveries d
+		SET next_attempt_at = NOW() + $2::interval
+		FROM due, webhooks w
+		WHERE d.id = due.id AND w.id = d.webhook_id
+		RETURNING ` + deliveryColumns + `, w.url, w.secret
+	`
+
+	rows, err := db.Pool.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to claim webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*WebhookDelivery{}
+	for rows.Next() {
+		d := &WebhookDelivery{}
+		err := rows.Scan(append(deliveryFields(d), &d.URL, &d.Secret)...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// RecordWebhookAttempt stores the outcome of a delivery attempt. A pending
+// status reschedules the delivery for next.
+func (db *DB) RecordWebhookAttempt(ctx context.Context, id int64, status string, statusCode *int, lastError *string, next time.Time) error {
+	query := `
+		UPDATE webhook_deliveries
+		SET status = $2,
+			attempts = attempts + 1,
+			last_status_code = $3,
+			last_error = $4,
+			next_attempt_at = $5,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN NOW() ELSE delivered_at END
+		WHERE id = $1
+	`
+
+	if _, err := db.Pool.Exec(ctx, query, id, status, statusCode, lastError, next); err != nil {
+		return fmt.Errorf("failed to record webhook attempt: %w", err)
+	}
+
+	return nil
+}
+
+// ListWebhookDeliveries retrieves the most recent deliveries of a webhook
+func (db *DB) ListWebhookDeliveries(ctx context.Context, webhookID, limit int) ([]*WebhookDelivery, error) {
+	query := `
+		SELECT ` + deliveryColumns + `
+		FROM webhook_deliveries d
+		WHERE d.webhook_id = $1
+		ORDER BY d.id DESC
+		LIMIT $2
+	`
+
+	rows, err := db.Pool.Query(ctx, query, webhookID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	defer rows.Close()
+
+	deliveries := []*WebhookDelivery{}
+	for rows.Next() {
+		d := &WebhookDelivery{}
+		if err := rows.Scan(deliveryFields(d)...); err != nil {
+			return nil, fmt.Errorf("failed to scan webhook delivery: %w", err)
+		}
+		deliveries = append(deliveries, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+// ReplayWebhookDelivery queues a fresh copy of a delivery with the same
+// payload. The original row is left as it was. It returns nil if no
+// delivery with that ID exists.
+func (db *DB) ReplayWebhookDelivery(ctx context.Context, id int64) (*WebhookDelivery, error) {
+	query := `
+		INSERT INTO webhook_deliveries AS d (webhook_id, event_type, poll_address, payload, replay_of, chain_id)
+		SELECT webhook_id, event_type, poll_address, payload, id, chain_id
+		FROM webhook_deliveries
+		WHERE id = $1 AND chain_id = $2
+		RETURNING ` + deliveryColumns
+
+	d := &WebhookDelivery{}
+	err := db.Pool.QueryRow(ctx, query, id, ChainFrom(ctx)).Scan(deliveryFields(d)...)
+	if err == pgx.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to replay webhook delivery: %w", err)
+	}
+
+	return d, nil
+}
+
+func scanWebhook(row pgx.Row) (*Webhook, error) {
+	hook := &Webhook{}
+	err := row.Scan(
+		&hook.ID, &hook.ChainID, &hook.URL, &hook.Secret, &hook.EventTypes,
+		&hook.PollAddress, &hook.Creator, &hook.Active, &hook.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+func deliveryFields(d *WebhookDelivery) []any {
+	return []any{
+		&d.ID, &d.ChainID, &d.WebhookID, &d.EventType, &d.PollAddress, &d.Payload, &d.Status,
+		&d.Attempts, &d.NextAttemptAt, &d.LastStatusCode, &d.LastError,
+		&d.ReplayOf, &d.CreatedAt, &d.DeliveredAt,
+	}
+}
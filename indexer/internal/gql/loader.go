@@ -0,0 +1,136 @@
+package gql
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+)
+
+// batchWait is how long a loader collects keys before fetching them.
+// Sibling fields resolve concurrently, so a short window is enough to
+// gather every key requested at one level of the query.
+const batchWait = 2 * time.Millisecond
+
+// batchFunc fetches the values for many keys in one round trip
+type batchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// batch is one pending or completed fetch
+type batch[K comparable, V any] struct {
+	keys   []K
+	done   chan struct{}
+	values map[K]V
+	err    error
+}
+
+// loader batches and caches lookups for the lifetime of one request,
+// turning per-item resolver calls into a single query per level
+type loader[K comparable, V any] struct {
+	fetch batchFunc[K, V]
+
+	mu      sync.Mutex
+	pending *batch[K, V]
+	seen    map[K]*batch[K, V]
+}
+
+func newLoader[K comparable, V any](fetch batchFunc[K, V]) *loader[K, V] {
+	return &loader[K, V]{
+		fetch: fetch,
+		seen:  make(map[K]*batch[K, V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it joins to be fetched.
+// Keys with no value return the zero value of V.
+func (l *loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+	b, ok := l.seen[key]
+	if !ok {
+		if l.pending == nil {
+			l.pending = &batch[K, V]{done: make(chan struct{})}
+			go l.dispatch(ctx, l.pending)
+		}
+		b = l.pending
+		b.keys = append(b.keys, key)
+		l.seen[key] = b
+	}
+	l.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return b.values[key], b.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+func (l *loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	time.Sleep(batchWait)
+
+	l.mu.Lock()
+	if l.pending == b {
+		l.pending = nil
+	}
+	keys := b.keys
+	l.mu.Unlock()
+
+	b.values, b.err = l.fetch(ctx, keys)
+	close(b.done)
+}
+
+// loaders holds the per-request loaders used by the resolvers
+type loaders struct {
+	polls        *loader[database.Address, *database.Poll]
+	voteCounts   *loader[database.Address, database.VoteCounts]
+	votesByPoll  *loader[database.Address, []*database.Vote]
+	votesByVoter *loader[database.Address, []*database.Vote]
+	results      *loader[database.Address, *database.Result]
+	events       *loader[database.Address, []*database.Event]
+
+	// policy and viewer decide which vote fields the request may see
+	policy privacy.Policy
+	viewer privacy.Viewer
+}
+
+func newLoaders(db *database.DB, policy privacy.Policy, viewer privacy.Viewer) *loaders {
+	return &loaders{
+		policy:       policy,
+		viewer:       viewer,
+		polls:        newLoader(db.GetPollsByAddresses),
+		voteCounts:   newLoader(db.GetVoteCountsByPolls),
+		votesByPoll:  newLoader(db.ListVotesByPolls),
+		votesByVoter: newLoader(db.ListVotesByVoters),
+		results:      newLoader(db.GetResultsByPolls),
+		events:       newLoader(db.ListEventsByContracts),
+	}
+}
+
+type loadersKey struct{}
+
+// WithLoaders returns a context carrying fresh loaders for one GraphQL request.
+// Loaders cache results, so they must never be shared between requests.
+// Votes are redacted by policy for the viewer attached with privacy.WithViewer.
+func WithLoaders(ctx context.Context, db *database.DB, policy privacy.Policy) context.Context {
+	return context.WithValue(ctx, loadersKey{}, newLoaders(db, policy, privacy.ViewerFrom(ctx)))
+}
+
+// pollState returns the state redaction depends on, loading the poll only
+// when the policy needs it
+func (l *loaders) pollState(ctx context.Context, address database.Address) (string, error) {
+	if !l.policy.NeedsPollState() {
+		return "", nil
+	}
+
+	poll, err := l.polls.Load(ctx, address)
+	if err != nil || poll == nil {
+		return "", err
+	}
+	return poll.State, nil
+}
+
+func loadersFrom(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
@@ -0,0 +1,328 @@
+package gql
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Resolver is the root Query resolver
+type Resolver struct {
+	db *database.DB
+}
+
+// pageArgs are the pagination arguments shared by list fields
+type pageArgs struct {
+	First  int32
+	Offset int32
+}
+
+func (a pageArgs) validate() error {
+	if a.First < 0 || a.First > maxPageSize {
+		return fmt.Errorf("first must be between 0 and %d", maxPageSize)
+	}
+	if a.Offset < 0 {
+		return fmt.Errorf("offset must be >= 0")
+	}
+	return nil
+}
+
+// page slices items according to args, rejecting out-of-range values
+func page[T any](items []T, args pageArgs) ([]T, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+
+	start := int(args.Offset)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + int(args.First)
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end], nil
+}
+
+func parseAddress(s string) (database.Address, error) {
+	address, err := database.ParseAddress(s)
+	if err != nil {
+		return database.Address{}, fmt.Errorf("invalid address %q", s)
+	}
+	return address, nil
+}
+
+// Poll resolves Query.poll
+func (r *Resolver) Poll(ctx context.Context, args struct{ Address string }) (*pollResolver, error) {
+	address, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := loadersFrom(ctx).polls.Load(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, nil
+	}
+	return &pollResolver{poll: poll}, nil
+}
+
+// Polls resolves Query.polls
+func (r *Resolver) Polls(ctx context.Context, args struct {
+	State *string
+	pageArgs
+}) ([]*pollResolver, error) {
+	if err := args.validate(); err != nil {
+		return nil, err
+	}
+
+	state := ""
+	if args.State != nil {
+		state = *args.State
+	}
+
+	polls, err := r.db.ListPolls(ctx, database.PollFilter{State: state}, int(args.First), int(args.Offset))
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*pollResolver, len(polls))
+	for i, poll := range polls {
+		resolvers[i] = &pollResolver{poll: poll}
+	}
+	return resolvers, nil
+}
+
+// Voter resolves Query.voter. Any well-formed address is a voter, possibly
+// with no votes.
+func (r *Resolver) Voter(args struct{ Address string }) (*voterResolver, error) {
+	address, err := parseAddress(args.Address)
+	if err != nil {
+		return nil, err
+	}
+	return &voterResolver{address: address}, nil
+}
+
+type pollResolver struct {
+	poll *database.Poll
+}
+
+func (r *pollResolver) ChainID() int32          { return int32(r.poll.ChainID) }
+func (r *pollResolver) Address() string         { return r.poll.ContractAddress.Hex() }
+func (r *pollResolver) Question() string        { return r.poll.Question }
+func (r *pollResolver) Options() []string       { return r.poll.Options }
+func (r *pollResolver) Duration() int32         { return int32(r.poll.Duration) }
+func (r *pollResolver) VoterMerkleRoot() string { return r.poll.VoterMerkleRoot }
+func (r *pollResolver) CreatedAt() graphql.Time { return graphql.Time{Time: r.poll.CreatedAt} }
+func (r *pollResolver) ClosesAt() graphql.Time  { return graphql.Time{Time: r.poll.ClosesAt} }
+func (r *pollResolver) State() string           { return r.poll.State }
+func (r *pollResolver) Creator() string         { return r.poll.Creator.Hex() }
+func (r *pollResolver) BlockNumber() int32      { return int32(r.poll.BlockNumber) }
+func (r *pollResolver) TransactionHash() string { return r.poll.TransactionHash }
+func (r *pollResolver) Tags() []string          { return r.poll.Tags }
+
+func (r *pollResolver) Stats(ctx context.Context) (*pollStatsResolver, error) {
+	counts, err := loadersFrom(ctx).voteCounts.Load(ctx, r.poll.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &pollStatsResolver{counts: counts}, nil
+}
+
+func (r *pollResolver) Votes(ctx context.Context, args struct {
+	RevealedOnly bool
+	pageArgs
+}) ([]*voteResolver, error) {
+	votes, err := loadersFrom(ctx).votesByPoll.Load(ctx, r.poll.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	if args.RevealedOnly {
+		revealed := make([]*database.Vote, 0, len(votes))
+		for _, vote := range votes {
+			if vote.Revealed {
+				revealed = append(revealed, vote)
+			}
+		}
+		votes = revealed
+	}
+
+	votes, err = page(votes, args.pageArgs)
+	if err != nil {
+		return nil, err
+	}
+	return voteResolvers(votes), nil
+}
+
+func (r *pollResolver) Result(ctx context.Context) (*resultResolver, error) {
+	result, err := loadersFrom(ctx).results.Load(ctx, r.poll.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, nil
+	}
+	return &resultResolver{result: result}, nil
+}
+
+func (r *pollResolver) Events(ctx context.Context, args pageArgs) ([]*eventResolver, error) {
+	events, err := loadersFrom(ctx).events.Load(ctx, r.poll.ContractAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err = page(events, args)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*eventResolver, len(events))
+	for i, event := range events {
+		resolvers[i] = &eventResolver{event: event}
+	}
+	return resolvers, nil
+}
+
+type pollStatsResolver struct {
+	counts database.VoteCounts
+}
+
+func (r *pollStatsResolver) TotalVotes() int32     { return int32(r.counts.Total) }
+func (r *pollStatsResolver) RevealedVotes() int32  { return int32(r.counts.Revealed) }
+func (r *pollStatsResolver) PendingReveals() int32 { return int32(r.counts.Total - r.counts.Revealed) }
+
+type voteResolver struct {
+	vote *database.Vote
+}
+
+func voteResolvers(votes []*database.Vote) []*voteResolver {
+	resolvers := make([]*voteResolver, len(votes))
+	for i, vote := range votes {
+		resolvers[i] = &voteResolver{vote: vote}
+	}
+	return resolvers
+}
+
+func (r *voteResolver) Poll(ctx context.Context) (*pollResolver, error) {
+	poll, err := loadersFrom(ctx).polls.Load(ctx, r.vote.PollAddress)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, nil
+	}
+	return &pollResolver{poll: poll}, nil
+}
+
+func (r *voteResolver) Voter() *voterResolver {
+	return &voterResolver{address: r.vote.Voter}
+}
+
+func (r *voteResolver) Commitment() string        { return r.vote.Commitment }
+func (r *voteResolver) Revealed() bool            { return r.vote.Revealed }
+func (r *voteResolver) CommittedAt() graphql.Time { return graphql.Time{Time: r.vote.CommittedAt} }
+func (r *voteResolver) BlockNumber() int32        { return int32(r.vote.BlockNumber) }
+func (r *voteResolver) TransactionHash() string   { return r.vote.TransactionHash }
+
+// Choice is the only vote field subject to redaction; the nonce is not in the schema
+func (r *voteResolver) Choice(ctx context.Context) (*int32, error) {
+	l := loadersFrom(ctx)
+	state, err := l.pollState(ctx, r.vote.PollAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	vote := l.policy.Vote(r.vote, state, l.viewer)
+	if vote.Choice == nil {
+		return nil, nil
+	}
+	choice := int32(*vote.Choice)
+	return &choice, nil
+}
+
+func (r *voteResolver) RevealedAt() *graphql.Time {
+	if r.vote.RevealedAt == nil {
+		return nil
+	}
+	return &graphql.Time{Time: *r.vote.RevealedAt}
+}
+
+func (r *voteResolver) RevealedBlock() *int32 {
+	if r.vote.RevealedBlock == nil {
+		return nil
+	}
+	block := int32(*r.vote.RevealedBlock)
+	return &block
+}
+
+type voterResolver struct {
+	address database.Address
+}
+
+func (r *voterResolver) Address() string { return r.address.Hex() }
+
+func (r *voterResolver) Votes(ctx context.Context, args pageArgs) ([]*voteResolver, error) {
+	votes, err := loadersFrom(ctx).votesByVoter.Load(ctx, r.address)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err = page(votes, args)
+	if err != nil {
+		return nil, err
+	}
+	return voteResolvers(votes), nil
+}
+
+type resultResolver struct {
+	result *database.Result
+}
+
+func (r *resultResolver) TotalVotes() int32       { return int32(r.result.TotalVotes) }
+func (r *resultResolver) TalliedAt() graphql.Time { return graphql.Time{Time: r.result.TalliedAt} }
+func (r *resultResolver) BlockNumber() int32      { return int32(r.result.BlockNumber) }
+func (r *resultResolver) TransactionHash() string { return r.result.TransactionHash }
+
+func (r *resultResolver) Poll(ctx context.Context) (*pollResolver, error) {
+	poll, err := loadersFrom(ctx).polls.Load(ctx, r.result.PollAddress)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, nil
+	}
+	return &pollResolver{poll: poll}, nil
+}
+
+func (r *resultResolver) VoteCounts() []int32 {
+	counts := make([]int32, len(r.result.VoteCounts))
+	for i, n := range r.result.VoteCounts {
+		counts[i] = int32(n)
+	}
+	return counts
+}
+
+type eventResolver struct {
+	event *database.Event
+}
+
+func (r *eventResolver) ContractAddress() string { return r.event.ContractAddress.Hex() }
+func (r *eventResolver) EventName() string       { return r.event.EventName }
+func (r *eventResolver) BlockNumber() int32      { return int32(r.event.BlockNumber) }
+func (r *eventResolver) BlockHash() string       { return r.event.BlockHash }
+func (r *eventResolver) TransactionHash() string { return r.event.TransactionHash }
+func (r *eventResolver) LogIndex() int32         { return int32(r.event.LogIndex) }
+
+func (r *eventResolver) EventData(ctx context.Context) (string, error) {
+	l := loadersFrom(ctx)
+	state, err := l.pollState(ctx, r.event.ContractAddress)
+	if err != nil {
+		return "", err
+	}
+	return l.policy.Event(r.event, state, l.viewer).EventData, nil
+}
@@ -0,0 +1,115 @@
+// Package gql serves the read model over GraphQL so clients can fetch a
+// poll with its stats, votes and results in one round trip. Nested fields
+// are resolved through per-request loaders that batch database lookups.
+package gql
+
+import (
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// Query limits keep a single request from fanning out without bound
+const (
+	maxDepth       = 8
+	maxParallelism = 100
+	maxPageSize    = 100
+)
+
+const schemaSDL = `
+schema {
+	query: Query
+}
+
+# RFC 3339 timestamp
+scalar Time
+
+enum PollState {
+	active
+	closed
+	tallied
+}
+
+type Query {
+	# A poll by contract address, in any letter case
+	poll(address: String!): Poll
+	# Indexed polls, newest first
+	polls(state: PollState, first: Int = 20, offset: Int = 0): [Poll!]!
+	# A voter and their history across polls
+	voter(address: String!): Voter!
+}
+
+type Poll {
+	# Chain the poll is indexed from
+	chainId: Int!
+	address: String!
+	question: String!
+	options: [String!]!
+	duration: Int!
+	voterMerkleRoot: String!
+	createdAt: Time!
+	closesAt: Time!
+	state: PollState!
+	creator: String!
+	blockNumber: Int!
+	transactionHash: String!
+	# Off-chain annotation tags
+	tags: [String!]!
+	stats: PollStats!
+	votes(revealedOnly: Boolean = false, first: Int = 100, offset: Int = 0): [Vote!]!
+	# Tallied results; null until the poll is tallied
+	result: Result
+	events(first: Int = 100, offset: Int = 0): [Event!]!
+}
+
+type PollStats {
+	totalVotes: Int!
+	revealedVotes: Int!
+	pendingReveals: Int!
+}
+
+type Vote {
+	poll: Poll
+	voter: Voter!
+	commitment: String!
+	# Null until the vote is revealed
+	choice: Int
+	revealed: Boolean!
+	committedAt: Time!
+	revealedAt: Time
+	revealedBlock: Int
+	blockNumber: Int!
+	transactionHash: String!
+}
+
+type Voter {
+	address: String!
+	votes(first: Int = 100, offset: Int = 0): [Vote!]!
+}
+
+type Result {
+	poll: Poll
+	voteCounts: [Int!]!
+	totalVotes: Int!
+	talliedAt: Time!
+	blockNumber: Int!
+	transactionHash: String!
+}
+
+type Event {
+	contractAddress: String!
+	eventName: String!
+	eventData: String!
+	blockNumber: Int!
+	blockHash: String!
+	transactionHash: String!
+	logIndex: Int!
+}
+`
+
+// NewSchema parses the schema and binds it to resolvers backed by db
+func NewSchema(db *database.DB) (*graphql.Schema, error) {
+	return graphql.ParseSchema(schemaSDL, &Resolver{db: db},
+		graphql.MaxDepth(maxDepth),
+		graphql.MaxParallelism(maxParallelism),
+	)
+}
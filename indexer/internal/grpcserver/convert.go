@@ -0,0 +1,136 @@
+package grpcserver
+
+import (
+	"time"
+
+	pollv1 "github.com/Cosmos-Harry/blockchain-qa/indexer/api/poll/v1"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Poll states and statuses by their indexed names
+var (
+	pollStates = map[string]pollv1.PollState{
+		"active":  pollv1.PollState_POLL_STATE_ACTIVE,
+		"closed":  pollv1.PollState_POLL_STATE_CLOSED,
+		"tallied": pollv1.PollState_POLL_STATE_TALLIED,
+	}
+	pollStatuses = map[string]pollv1.PollStatus{
+		database.StatusActive:  pollv1.PollStatus_POLL_STATUS_ACTIVE,
+		database.StatusOverdue: pollv1.PollStatus_POLL_STATUS_OVERDUE,
+		database.StatusClosed:  pollv1.PollStatus_POLL_STATUS_CLOSED,
+		database.StatusTallied: pollv1.PollStatus_POLL_STATUS_TALLIED,
+	}
+)
+
+// stateName returns the indexed name of a state filter, "" for unspecified,
+// and false for values this server does not know
+func stateName(state pollv1.PollState) (string, bool) {
+	if state == pollv1.PollState_POLL_STATE_UNSPECIFIED {
+		return "", true
+	}
+	for name, s := range pollStates {
+		if s == state {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// statusName is stateName for status filters
+func statusName(status pollv1.PollStatus) (string, bool) {
+	if status == pollv1.PollStatus_POLL_STATUS_UNSPECIFIED {
+		return "", true
+	}
+	for name, s := range pollStatuses {
+		if s == status {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+	return timestamppb.New(*t)
+}
+
+func toPoll(poll *database.Poll) *pollv1.Poll {
+	return &pollv1.Poll{
+		Address:         poll.ContractAddress.Hex(),
+		Question:        poll.Question,
+		Options:         poll.Options,
+		DurationSeconds: int64(poll.Duration),
+		VoterMerkleRoot: poll.VoterMerkleRoot,
+		CreatedAt:       timestamp(&poll.CreatedAt),
+		ClosesAt:        timestamp(&poll.ClosesAt),
+		State:           pollStates[poll.State],
+		Status:          pollStatuses[poll.Status],
+		OverdueSeconds:  poll.OverdueSeconds,
+		Creator:         poll.Creator.Hex(),
+		BlockNumber:     poll.BlockNumber,
+		TransactionHash: poll.TransactionHash,
+		Tags:            poll.Tags,
+		ChainId:         poll.ChainID,
+	}
+}
+
+func toVote(vote *database.Vote) *pollv1.Vote {
+	pb := &pollv1.Vote{
+		PollAddress:     vote.PollAddress.Hex(),
+		Voter:           vote.Voter.Hex(),
+		Commitment:      vote.Commitment,
+		Revealed:        vote.Revealed,
+		CommittedAt:     timestamp(&vote.CommittedAt),
+		RevealedAt:      timestamp(vote.RevealedAt),
+		RevealedBlock:   vote.RevealedBlock,
+		BlockNumber:     vote.BlockNumber,
+		TransactionHash: vote.TransactionHash,
+	}
+	if vote.Choice != nil {
+		choice := int64(*vote.Choice)
+		pb.Choice = &choice
+	}
+	return pb
+}
+
+func toResult(result *database.Result) *pollv1.Result {
+	counts := make([]int64, len(result.VoteCounts))
+	for i, n := range result.VoteCounts {
+		counts[i] = int64(n)
+	}
+
+	return &pollv1.Result{
+		PollAddress:      result.PollAddress.Hex(),
+		VoteCounts:       counts,
+		TotalVotes:       int64(result.TotalVotes),
+		TalliedAt:        timestamp(&result.TalliedAt),
+		BlockNumber:      result.BlockNumber,
+		TransactionHash:  result.TransactionHash,
+		ProvisionalMatch: result.ProvisionalMatch,
+	}
+}
+
+func toEvent(event *database.Event) *pollv1.Event {
+	return &pollv1.Event{
+		Id:              int64(event.ID),
+		ContractAddress: event.ContractAddress.Hex(),
+		EventName:       event.EventName,
+		EventData:       event.EventData,
+		BlockNumber:     event.BlockNumber,
+		BlockHash:       event.BlockHash,
+		TransactionHash: event.TransactionHash,
+		LogIndex:        int64(event.LogIndex),
+		GasUsed:         event.GasUsed,
+	}
+}
+
+func toCounts(counts database.VoteCounts) *pollv1.VoteCounts {
+	return &pollv1.VoteCounts{
+		TotalVotes:     int64(counts.Total),
+		RevealedVotes:  int64(counts.Revealed),
+		PendingReveals: int64(counts.Total - counts.Revealed),
+	}
+}
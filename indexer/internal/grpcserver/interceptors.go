@@ -0,0 +1,134 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func (s *Server) unaryInterceptor(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	ctx, err := s.admit(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *Server) streamInterceptor(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.admit(stream.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &viewerStream{ServerStream: stream, ctx: ctx})
+}
+
+// viewerStream carries the context admit prepared into a stream handler
+type viewerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (v *viewerStream) Context() context.Context {
+	return v.ctx
+}
+
+// admit authenticates a call, takes a token from the caller's rate limit
+// bucket and attaches the caller's privacy viewer and chain to the
+// context. A stream counts as one call however long it stays open.
+func (s *Server) admit(ctx context.Context) (context.Context, error) {
+	var key *database.APIKey
+	if plaintext := extractKey(ctx); plaintext != "" {
+		var err error
+		key, err = auth.Lookup(ctx, s.db, plaintext)
+		if err != nil {
+			return nil, internalError(ctx, err, "failed to verify api key")
+		}
+		if key == nil {
+			return nil, status.Error(codes.Unauthenticated, "invalid api key")
+		}
+	}
+
+	if s.opts.AuthRequired {
+		if key == nil {
+			return nil, status.Error(codes.Unauthenticated, "api key required")
+		}
+		if !auth.HasScope(key, auth.ScopeRead) {
+			return nil, status.Errorf(codes.PermissionDenied, "api key lacks %s scope", auth.ScopeRead)
+		}
+	}
+
+	if s.opts.Limiter != nil {
+		decision, _, err := auth.Take(ctx, s.opts.Limiter, auth.BucketsFor(key, peerIP(ctx), s.opts.Limit))
+		if err != nil {
+			// Fail open: rate limiting must not take the API down
+			log.Printf("Warning: rate limiter error: %v\n", err)
+		} else if !decision.Allowed {
+			return nil, status.Errorf(codes.ResourceExhausted, "rate limit exceeded; retry after %s", decision.RetryAfter.Round(time.Second))
+		}
+	}
+
+	chainID, err := s.chainOf(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = database.WithChain(ctx, chainID)
+	return privacy.WithViewer(ctx, privacy.ViewerForKey(key)), nil
+}
+
+// chainOf returns the chain named by the x-chain-id metadata entry, or the
+// default chain when there is none, mirroring /api/chains/:chainId
+func (s *Server) chainOf(ctx context.Context) (int64, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get("x-chain-id")
+	if len(values) == 0 || values[0] == "" {
+		return s.opts.Chains.Default, nil
+	}
+
+	chainID, err := strconv.ParseInt(values[0], 10, 64)
+	if err != nil {
+		return 0, status.Error(codes.InvalidArgument, "x-chain-id must be an integer")
+	}
+	if s.opts.Chains.Get(chainID) == nil {
+		return 0, status.Errorf(codes.NotFound, "chain %d is not served by this API", chainID)
+	}
+	return chainID, nil
+}
+
+// extractKey reads the API key from the x-api-key metadata entry or a
+// bearer token, mirroring the REST headers
+func extractKey(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("x-api-key"); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	if values := md.Get("authorization"); len(values) > 0 && strings.HasPrefix(values[0], "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(values[0], "Bearer "))
+	}
+	return ""
+}
+
+// peerIP returns the client's IP address for anonymous rate limiting
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		return p.Addr.String()
+	}
+	return host
+}
@@ -0,0 +1,129 @@
+package grpcserver
+
+import (
+	"context"
+	"strings"
+
+	pollv1 "github.com/Cosmos-Harry/blockchain-qa/indexer/api/poll/v1"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Page size bounds for ListPolls, matching GET /api/polls
+const (
+	defaultPollLimit = 20
+	maxPollLimit     = 100
+)
+
+// GetPoll returns a poll by contract address
+func (s *Server) GetPoll(ctx context.Context, req *pollv1.GetPollRequest) (*pollv1.Poll, error) {
+	address, err := parseAddress("address", req.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.loadPoll(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	return toPoll(poll), nil
+}
+
+// ListPolls lists indexed polls, newest first
+func (s *Server) ListPolls(ctx context.Context, req *pollv1.ListPollsRequest) (*pollv1.ListPollsResponse, error) {
+	state, ok := stateName(req.GetState())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown state")
+	}
+	pollStatus, ok := statusName(req.GetStatus())
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, "unknown status")
+	}
+
+	limit := int(req.GetLimit())
+	if limit == 0 {
+		limit = defaultPollLimit
+	}
+	if limit < 1 || limit > maxPollLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "limit must be between 1 and %d", maxPollLimit)
+	}
+	if req.GetOffset() < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset must not be negative")
+	}
+
+	now, err := s.chainTime(ctx)
+	if err != nil {
+		return nil, internalError(ctx, err, "failed to get chain time")
+	}
+
+	filter := database.PollFilter{
+		State:     state,
+		Status:    pollStatus,
+		Tag:       strings.ToLower(req.GetTag()),
+		ChainTime: now,
+	}
+	polls, err := s.db.ListPolls(ctx, filter, limit, int(req.GetOffset()))
+	if err != nil {
+		return nil, internalError(ctx, err, "failed to list polls")
+	}
+
+	resp := &pollv1.ListPollsResponse{Polls: make([]*pollv1.Poll, len(polls))}
+	for i, poll := range polls {
+		poll.SetChainTime(now)
+		resp.Polls[i] = toPoll(poll)
+	}
+
+	return resp, nil
+}
+
+// ListVotes lists a poll's vote commitments, redacted for the caller
+func (s *Server) ListVotes(ctx context.Context, req *pollv1.ListVotesRequest) (*pollv1.ListVotesResponse, error) {
+	address, err := parseAddress("poll_address", req.GetPollAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.loadPoll(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	votes, err := s.db.ListVotesByPoll(ctx, address, req.GetRevealedOnly())
+	if err != nil {
+		return nil, internalError(ctx, err, "failed to list votes")
+	}
+	votes = s.opts.Policy.Votes(votes, poll.State, privacy.ViewerFrom(ctx))
+
+	resp := &pollv1.ListVotesResponse{Votes: make([]*pollv1.Vote, len(votes))}
+	for i, vote := range votes {
+		resp.Votes[i] = toVote(vote)
+	}
+
+	return resp, nil
+}
+
+// GetResults returns the tallied results of a poll
+func (s *Server) GetResults(ctx context.Context, req *pollv1.GetResultsRequest) (*pollv1.Result, error) {
+	address, err := parseAddress("poll_address", req.GetPollAddress())
+	if err != nil {
+		return nil, err
+	}
+
+	poll, err := s.loadPoll(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.db.GetResultByPoll(ctx, address)
+	if err != nil {
+		return nil, internalError(ctx, err, "failed to retrieve results")
+	}
+	if result == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "poll is %s; results are available once it is tallied", poll.Status)
+	}
+
+	return toResult(result), nil
+}
@@ -0,0 +1,105 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	pollv1 "github.com/Cosmos-Harry/blockchain-qa/indexer/api/poll/v1"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// watchInterval is how often WatchPoll checks for newly stored events
+	watchInterval = 2 * time.Second
+	// watchBatch caps the events read per check
+	watchBatch = 100
+)
+
+// WatchPoll streams the poll's current state, then one update per event
+// the indexer stores for it, until the poll is tallied or the client
+// goes away. Events are followed by storage order, so events a reindex
+// stores late are still delivered.
+func (s *Server) WatchPoll(req *pollv1.WatchPollRequest, stream pollv1.PollService_WatchPollServer) error {
+	ctx := stream.Context()
+
+	address, err := parseAddress("poll_address", req.GetPollAddress())
+	if err != nil {
+		return err
+	}
+
+	poll, err := s.loadPoll(ctx, address)
+	if err != nil {
+		return err
+	}
+
+	var lastID int
+	if !req.GetReplay() {
+		if lastID, err = s.db.GetLastEventID(ctx, address); err != nil {
+			return internalError(ctx, err, "failed to read poll events")
+		}
+	}
+
+	counts, err := s.counts(ctx, address)
+	if err != nil {
+		return err
+	}
+	if err := stream.Send(&pollv1.PollUpdate{Poll: toPoll(poll), Counts: counts}); err != nil {
+		return err
+	}
+
+	viewer := privacy.ViewerFrom(ctx)
+	ticker := time.NewTicker(watchInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.db.ListEventsAfterID(ctx, address, lastID, watchBatch)
+		if err != nil {
+			return internalError(ctx, err, "failed to read poll events")
+		}
+
+		if len(events) > 0 {
+			if poll, err = s.loadPoll(ctx, address); err != nil {
+				return err
+			}
+			if counts, err = s.counts(ctx, address); err != nil {
+				return err
+			}
+
+			pb := toPoll(poll)
+			for _, event := range events {
+				event = s.opts.Policy.Event(event, poll.State, viewer)
+				update := &pollv1.PollUpdate{Poll: pb, Counts: counts, Event: toEvent(event)}
+				if err := stream.Send(update); err != nil {
+					return err
+				}
+				lastID = event.ID
+			}
+
+			// More events are waiting; read them without sleeping
+			if len(events) == watchBatch {
+				continue
+			}
+		}
+
+		if poll.State == database.StatusTallied {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return status.FromContextError(ctx.Err()).Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// counts returns a poll's vote counts
+func (s *Server) counts(ctx context.Context, address database.Address) (*pollv1.VoteCounts, error) {
+	counts, err := s.db.GetVoteCountsByPolls(ctx, []database.Address{address})
+	if err != nil {
+		return nil, internalError(ctx, err, "failed to get vote counts")
+	}
+	return toCounts(counts[address]), nil
+}
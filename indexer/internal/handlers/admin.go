@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// AdminHandler handles operator-only HTTP requests
+type AdminHandler struct {
+	db *database.DB
+}
+
+// NewAdminHandler creates a new admin handler
+func NewAdminHandler(db *database.DB) *AdminHandler {
+	return &AdminHandler{
+		db: db,
+	}
+}
+
+// CreateAPIKeyRequest is the body accepted by CreateAPIKey
+type CreateAPIKeyRequest struct {
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	RateLimit *int     `json:"rate_limit,omitempty"`
+}
+
+// CreateAPIKeyResponse is returned by CreateAPIKey.
+// The plaintext key is only ever returned here.
+type CreateAPIKeyResponse struct {
+	*database.APIKey
+	Key string `json:"key"`
+}
+
+// APIKeyListResponse is returned by ListAPIKeys
+type APIKeyListResponse struct {
+	Keys  []*database.APIKey `json:"keys"`
+	Count int                `json:"count"`
+}
+
+// CreateAPIKey issues a new API key
+// POST /api/admin/keys
+func (h *AdminHandler) CreateAPIKey(c *fiber.Ctx) error {
+	var req CreateAPIKeyRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	if req.Name == "" {
+		return apierror.BadRequest(c, "name is required")
+	}
+	if len(req.Scopes) == 0 {
+		req.Scopes = []string{auth.ScopeRead}
+	}
+	for _, scope := range req.Scopes {
+		if !auth.ValidScope(scope) {
+			return apierror.BadRequest(c, "unknown scope: "+scope)
+		}
+	}
+	if req.RateLimit != nil && *req.RateLimit <= 0 {
+		return apierror.BadRequest(c, "rate_limit must be positive")
+	}
+
+	plaintext, hash, err := auth.GenerateKey()
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to generate api key")
+	}
+
+	key := &database.APIKey{
+		Name:      req.Name,
+		KeyPrefix: auth.DisplayPrefix(plaintext),
+		KeyHash:   hash,
+		Scopes:    req.Scopes,
+		RateLimit: req.RateLimit,
+	}
+
+	ctx := c.UserContext()
+	if err := h.db.CreateAPIKey(ctx, key); err != nil {
+		return apierror.InternalError(c, err, "failed to create api key")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(CreateAPIKeyResponse{
+		APIKey: key,
+		Key:    plaintext,
+	})
+}
+
+// ListAPIKeys lists all API keys without their secrets
+// GET /api/admin/keys
+func (h *AdminHandler) ListAPIKeys(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	keys, err := h.db.ListAPIKeys(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list api keys")
+	}
+
+	return c.JSON(APIKeyListResponse{
+		Keys:  keys,
+		Count: len(keys),
+	})
+}
+
+// RevokeAPIKey revokes an API key
+// DELETE /api/admin/keys/:id
+func (h *AdminHandler) RevokeAPIKey(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "id", "invalid api key id")
+	}
+
+	ctx := c.UserContext()
+	revoked, err := h.db.RevokeAPIKey(ctx, id)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to revoke api key")
+	}
+	if !revoked {
+		return apierror.NotFoundError(c, apierror.APIKeyNotFound, "api key not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
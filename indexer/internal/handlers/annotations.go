@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// Annotation limits
+const (
+	MaxPollTags       = 32
+	MaxNotesLength    = 4000
+	MaxScenarioLength = 100
+)
+
+// tagPattern is the accepted form of a tag after lowercasing,
+// e.g. "late-oracle" or "fuzz-run-42"
+var tagPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._:-]{0,63}$`)
+
+// AnnotationHandler handles off-chain poll annotations
+type AnnotationHandler struct {
+	db    *database.DB
+	redis *redis.Client
+}
+
+// NewAnnotationHandler creates a new annotation handler
+func NewAnnotationHandler(db *database.DB, redis *redis.Client) *AnnotationHandler {
+	return &AnnotationHandler{
+		db:    db,
+		redis: redis,
+	}
+}
+
+// AnnotationRequest is the body accepted by SetAnnotation. It replaces the
+// whole annotation.
+type AnnotationRequest struct {
+	Tags       []string `json:"tags"`
+	Notes      string   `json:"notes"`
+	ScenarioID string   `json:"scenario_id,omitempty"`
+}
+
+// AddTagsRequest is the body accepted by AddTags
+type AddTagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// GetAnnotation returns the annotation of a poll
+// GET /api/polls/:address/annotations
+func (h *AnnotationHandler) GetAnnotation(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	annotation, err := h.load(c, address)
+	if err != nil || annotation == nil {
+		return err
+	}
+
+	return c.JSON(annotation)
+}
+
+// SetAnnotation replaces the tags, notes and scenario ID of a poll
+// PUT /api/polls/:address/annotations
+func (h *AnnotationHandler) SetAnnotation(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	var req AnnotationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return apierror.BadRequest(c, err.Error())
+	}
+	if len(req.Notes) > MaxNotesLength {
+		return apierror.BadRequest(c, fmt.Sprintf("notes must be at most %d characters", MaxNotesLength))
+	}
+	scenario := strings.TrimSpace(req.ScenarioID)
+	if len(scenario) > MaxScenarioLength {
+		return apierror.BadRequest(c, fmt.Sprintf("scenario_id must be at most %d characters", MaxScenarioLength))
+	}
+
+	annotation, err := h.load(c, address)
+	if err != nil || annotation == nil {
+		return err
+	}
+
+	annotation.Tags = tags
+	annotation.Notes = req.Notes
+	annotation.ScenarioID = nil
+	if scenario != "" {
+		annotation.ScenarioID = &scenario
+	}
+	return h.save(c, annotation)
+}
+
+// AddTags adds tags to a poll, keeping its existing tags, notes and scenario
+// POST /api/polls/:address/annotations/tags
+func (h *AnnotationHandler) AddTags(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	var req AddTagsRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+	if len(req.Tags) == 0 {
+		return apierror.BadRequest(c, "tags is required")
+	}
+
+	annotation, err := h.load(c, address)
+	if err != nil || annotation == nil {
+		return err
+	}
+
+	tags, err := normalizeTags(append(annotation.Tags, req.Tags...))
+	if err != nil {
+		return apierror.BadRequest(c, err.Error())
+	}
+
+	annotation.Tags = tags
+	return h.save(c, annotation)
+}
+
+// RemoveTag removes one tag from a poll. Removing a tag the poll does not
+// carry is not an error.
+// DELETE /api/polls/:address/annotations/tags/:tag
+func (h *AnnotationHandler) RemoveTag(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	annotation, err := h.load(c, address)
+	if err != nil || annotation == nil {
+		return err
+	}
+
+	remove := strings.ToLower(c.Params("tag"))
+	tags := make([]string, 0, len(annotation.Tags))
+	for _, tag := range annotation.Tags {
+		if tag != remove {
+			tags = append(tags, tag)
+		}
+	}
+	if len(tags) == len(annotation.Tags) {
+		return c.JSON(annotation)
+	}
+
+	annotation.Tags = tags
+	return h.save(c, annotation)
+}
+
+// DeleteAnnotation removes the tags, notes and scenario ID of a poll
+// DELETE /api/polls/:address/annotations
+func (h *AnnotationHandler) DeleteAnnotation(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	if _, err := h.db.DeletePollAnnotation(ctx, address); err != nil {
+		return apierror.InternalError(c, err, "failed to delete poll annotation")
+	}
+	h.invalidate(c, address)
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// load returns the annotation of an indexed poll, empty if it has none.
+// When it returns nil the error response has already been written.
+func (h *AnnotationHandler) load(c *fiber.Ctx, address database.Address) (*database.PollAnnotation, error) {
+	ctx := c.UserContext()
+
+	poll, err := h.db.GetPollByAddress(ctx, address)
+	if err != nil {
+		return nil, apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+	if poll == nil {
+		return nil, apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
+	}
+
+	annotation, err := h.db.GetPollAnnotation(ctx, address)
+	if err != nil {
+		return nil, apierror.InternalError(c, err, "failed to retrieve poll annotation")
+	}
+	if annotation == nil {
+		annotation = &database.PollAnnotation{PollAddress: address, Tags: []string{}}
+	}
+
+	return annotation, nil
+}
+
+// save stores an annotation on behalf of the calling key and returns it
+func (h *AnnotationHandler) save(c *fiber.Ctx, annotation *database.PollAnnotation) error {
+	if key := auth.KeyFromContext(c); key != nil {
+		annotation.UpdatedBy = &key.Name
+	}
+
+	if err := h.db.UpsertPollAnnotation(c.UserContext(), annotation); err != nil {
+		return apierror.InternalError(c, err, "failed to save poll annotation")
+	}
+	h.invalidate(c, annotation.PollAddress)
+
+	return c.JSON(annotation)
+}
+
+// invalidate drops the cached poll so its tags are served fresh
+func (h *AnnotationHandler) invalidate(c *fiber.Ctx, address database.Address) {
+	if h.redis != nil {
+		h.redis.Del(c.UserContext(), pollCacheKey(c.UserContext(), address))
+	}
+}
+
+// normalizeTags lowercases and deduplicates tags, keeping their order, and
+// checks their form and number
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagPattern.MatchString(tag) {
+			return nil, fmt.Errorf("invalid tag %q: use up to 64 letters, digits, '.', '_', ':' or '-'", tag)
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			out = append(out, tag)
+		}
+	}
+	if len(out) > MaxPollTags {
+		return nil, fmt.Errorf("a poll can have at most %d tags", MaxPollTags)
+	}
+	return out, nil
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaxBatchPolls caps the number of addresses in one batch lookup
+const MaxBatchPolls = 100
+
+// BatchPollRequest is the body accepted by GetPollsBatch
+type BatchPollRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+// BatchPollItem is the outcome for one requested address. Either Poll and
+// Stats are set, or Error says why the address could not be served.
+type BatchPollItem struct {
+	Address string             `json:"address"` // As given in the request
+	Poll    *database.Poll     `json:"poll,omitempty"`
+	Stats   *VoteStatsResponse `json:"stats,omitempty"`
+	Result  *database.Result   `json:"result,omitempty"` // Set once the poll is tallied
+	Error   *apierror.Error    `json:"error,omitempty"`
+}
+
+// BatchPollResponse is returned by GetPollsBatch. Items are in request order.
+type BatchPollResponse struct {
+	Items    []*BatchPollItem `json:"items"`
+	Found    int              `json:"found"`
+	NotFound int              `json:"not_found"` // Invalid or unknown addresses
+}
+
+// GetPollsBatch returns several polls with their vote counts and results.
+// Invalid or unknown addresses are reported on their item rather than
+// failing the request.
+// POST /api/polls/batch
+func (h *PollHandler) GetPollsBatch(c *fiber.Ctx) error {
+	var req BatchPollRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	if len(req.Addresses) == 0 {
+		return apierror.BadRequest(c, "addresses is required")
+	}
+	if len(req.Addresses) > MaxBatchPolls {
+		return apierror.BadRequest(c, fmt.Sprintf("at most %d addresses are allowed", MaxBatchPolls))
+	}
+
+	items := make([]*BatchPollItem, len(req.Addresses))
+	parsed := make([]database.Address, len(req.Addresses))
+	var addresses []database.Address
+	for i, raw := range req.Addresses {
+		items[i] = &BatchPollItem{Address: raw}
+		address, err := database.ParseAddress(raw)
+		if err != nil {
+			items[i].Error = &apierror.Error{Code: apierror.InvalidAddress, Message: "invalid poll address"}
+			continue
+		}
+		parsed[i] = address
+		addresses = append(addresses, address)
+	}
+
+	ctx := c.UserContext()
+
+	polls, err := h.db.GetPollsByAddresses(ctx, addresses)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve polls")
+	}
+
+	var found []database.Address
+	for address := range polls {
+		found = append(found, address)
+	}
+
+	counts, err := h.db.GetVoteCountsByPolls(ctx, found)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get vote counts")
+	}
+
+	results, err := h.db.GetResultsByPolls(ctx, found)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve results")
+	}
+
+	now, _, err := h.chainTime(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get chain time")
+	}
+	for _, poll := range polls {
+		poll.SetChainTime(now)
+	}
+
+	resp := BatchPollResponse{Items: items}
+	for i, item := range items {
+		if item.Error != nil {
+			resp.NotFound++
+			continue
+		}
+
+		poll, ok := polls[parsed[i]]
+		if !ok {
+			item.Error = &apierror.Error{Code: apierror.PollNotFound, Message: "poll not found"}
+			resp.NotFound++
+			continue
+		}
+
+		count := counts[parsed[i]]
+		item.Poll = poll
+		item.Stats = &VoteStatsResponse{
+			PollAddress:    poll.ContractAddress,
+			TotalVotes:     count.Total,
+			RevealedVotes:  count.Revealed,
+			PendingReveals: count.Total - count.Revealed,
+		}
+		item.Result = results[parsed[i]]
+		resp.Found++
+	}
+
+	return c.JSON(resp)
+}
@@ -0,0 +1,78 @@
+package handlers
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ChainScope scopes each request to a chain. Routes under
+// /api/chains/:chainId serve the chain they name and answer 404 for one the
+// API does not serve; every other route serves the default chain.
+func ChainScope(chains *blockchain.Chains) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		chainID := chains.Default
+		if raw := c.Params("chainId"); raw != "" {
+			id, err := strconv.ParseInt(raw, 10, 64)
+			if err != nil || chains.Get(id) == nil {
+				return apierror.NotFoundError(c, apierror.ChainNotFound, fmt.Sprintf("chain %s is not served by this API", raw))
+			}
+			chainID = id
+		}
+
+		c.SetUserContext(database.WithChain(c.UserContext(), chainID))
+		return c.Next()
+	}
+}
+
+// ChainHandler lists the chains the API serves
+type ChainHandler struct {
+	chains *blockchain.Chains
+}
+
+// NewChainHandler creates a new chain handler
+func NewChainHandler(chains *blockchain.Chains) *ChainHandler {
+	return &ChainHandler{
+		chains: chains,
+	}
+}
+
+// ChainInfo describes a served chain
+type ChainInfo struct {
+	ChainID   int64              `json:"chain_id"`
+	Default   bool               `json:"default"`
+	Factories []database.Address `json:"factories"` // Known to the API; used to read polls not indexed yet
+	Node      bool               `json:"node"`      // Whether the API reaches a node for the chain
+}
+
+// ChainListResponse is returned by ListChains
+type ChainListResponse struct {
+	Chains       []ChainInfo `json:"chains"`
+	DefaultChain int64       `json:"default_chain"`
+}
+
+// ListChains lists the chains served under /api/chains/:chainId
+// GET /api/chains
+func (h *ChainHandler) ListChains(c *fiber.Ctx) error {
+	resp := ChainListResponse{Chains: []ChainInfo{}, DefaultChain: h.chains.Default}
+
+	for _, id := range h.chains.IDs() {
+		chain := h.chains.Get(id)
+		info := ChainInfo{
+			ChainID:   id,
+			Default:   id == h.chains.Default,
+			Factories: make([]database.Address, len(chain.Factories)),
+			Node:      chain.Client != nil,
+		}
+		for i, factory := range chain.Factories {
+			info.Factories[i] = database.Address(factory)
+		}
+		resp.Chains = append(resp.Chains, info)
+	}
+
+	return c.JSON(resp)
+}
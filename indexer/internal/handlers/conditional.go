@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Cache-Control for poll responses. Indexed data that can still change is
+// revalidated on every use. Tallied results only change when an admin
+// reindexes the poll, so they are cached briefly and then revalidated
+// against the ETag.
+const (
+	cacheRevalidate = "no-cache"
+	cacheFinal      = "public, max-age=300"
+)
+
+// conditional holds the validators of a poll response: an ETag tied to the
+// last block that touched the poll, and the time that block was indexed
+type conditional struct {
+	etag     string
+	modified time.Time
+	cache    string
+}
+
+// newConditional derives validators from a poll version, or returns nil
+// for a poll that is not indexed. resource names the representation and
+// parts carry anything else it depends on, such as the viewer, so that
+// different representations of the same poll never share a tag.
+func newConditional(version *database.PollVersion, resource string, parts ...string) *conditional {
+	if version == nil {
+		return nil
+	}
+
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s|%s|%d|%d|%s|%s", resource, version.State, version.LastBlock,
+		version.ModifiedAt.UnixNano(), unixNano(version.AnnotatedAt), unixNano(version.TreeAt))
+	for _, part := range parts {
+		fmt.Fprintf(hash, "|%s", part)
+	}
+
+	return &conditional{
+		etag:     fmt.Sprintf(`W/"%d-%x"`, version.LastBlock, hash.Sum(nil)[:8]),
+		modified: version.ModifiedAt.UTC().Truncate(time.Second),
+		cache:    cacheRevalidate,
+	}
+}
+
+// pollConditional is newConditional for the poll itself, whose status and
+// overdue time also move with the chain clock between blocks
+func pollConditional(version *database.PollVersion, now time.Time) *conditional {
+	if version == nil {
+		return nil
+	}
+
+	derived := database.Poll{State: version.State, ClosesAt: version.ClosesAt}
+	derived.SetChainTime(now)
+	if derived.OverdueSeconds == nil {
+		return newConditional(version, "poll", derived.Status)
+	}
+
+	cond := newConditional(version, "poll", derived.Status, fmt.Sprint(*derived.OverdueSeconds))
+	cond.modified = now.UTC().Truncate(time.Second)
+	return cond
+}
+
+func unixNano(t *time.Time) string {
+	if t == nil {
+		return "-"
+	}
+	return fmt.Sprint(t.UnixNano())
+}
+
+// fresh reports whether the client's copy is current. If-None-Match takes
+// precedence over If-Modified-Since, as RFC 9110 requires.
+func (cond *conditional) fresh(c *fiber.Ctx) bool {
+	if cond == nil {
+		return false
+	}
+
+	if match := c.Get(fiber.HeaderIfNoneMatch); match != "" {
+		return etagMatches(match, cond.etag)
+	}
+	if since := c.Get(fiber.HeaderIfModifiedSince); since != "" {
+		t, err := http.ParseTime(since)
+		return err == nil && !cond.modified.After(t)
+	}
+	return false
+}
+
+// apply sets the validators and Cache-Control on a successful response
+func (cond *conditional) apply(c *fiber.Ctx) {
+	if cond == nil {
+		return
+	}
+	c.Set(fiber.HeaderETag, cond.etag)
+	c.Set(fiber.HeaderLastModified, cond.modified.Format(http.TimeFormat))
+	c.Set(fiber.HeaderCacheControl, cond.cache)
+}
+
+// notModified answers a fresh request with 304 and the current validators
+func (cond *conditional) notModified(c *fiber.Ctx) error {
+	cond.apply(c)
+	return c.SendStatus(fiber.StatusNotModified)
+}
+
+// etagMatches applies the weak comparison If-None-Match uses to a
+// comma-separated list of tags
+func etagMatches(header, etag string) bool {
+	if strings.TrimSpace(header) == "*" {
+		return true
+	}
+
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(header, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
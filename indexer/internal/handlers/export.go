@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/audit"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"github.com/gofiber/fiber/v2"
+)
+
+// exportTimeout bounds how long streaming a single export may query the database
+const exportTimeout = 2 * time.Minute
+
+// ExportPoll streams the audit bundle for a poll as JSON, CSV or a ZIP archive
+// GET /api/polls/:address/export?format=json
+func (h *PollHandler) ExportPoll(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	format := c.Query("format", audit.FormatJSON)
+	switch format {
+	case audit.FormatJSON, audit.FormatCSV, audit.FormatZIP:
+	default:
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "format", "format must be json, csv or zip")
+	}
+
+	ctx := c.UserContext()
+
+	poll, err := h.db.GetPollByAddress(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+
+	if poll == nil {
+		return apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
+	}
+
+	c.Set(fiber.HeaderContentType, audit.ContentType(format))
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="poll-%s-audit.%s"`, address.Lower(), format))
+
+	viewer := privacy.ViewerOf(c)
+	chainID := database.ChainFrom(ctx)
+
+	// The body is written after the handler returns, so errors past this
+	// point can only truncate the download
+	c.Context().SetBodyStreamWriter(func(w *bufio.Writer) {
+		// The route deadline has been cancelled by the time this runs, so
+		// the export gets its own, carrying the request's chain
+		ctx, cancel := context.WithTimeout(database.WithChain(context.Background(), chainID), exportTimeout)
+		defer cancel()
+
+		sink, err := audit.NewSink(format, w)
+		if err != nil {
+			log.Printf("Export of %s failed: %v\n", address, err)
+			return
+		}
+		if err := audit.Export(ctx, h.db, poll, sink, h.policy, viewer); err != nil {
+			log.Printf("Export of %s failed: %v\n", address, err)
+		}
+		w.Flush()
+	})
+
+	return nil
+}
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// pollSourceChain marks a poll read from its contract rather than the index
+const pollSourceChain = "chain"
+
+// chainPollTTL is how long a chain read, or the absence of a poll at an
+// address, is reused before the contracts are read again
+const chainPollTTL = 15 * time.Second
+
+// ChainPollResponse is returned by GetPoll for a poll the indexer has not
+// stored yet, read straight from its contract
+type ChainPollResponse struct {
+	*database.Poll
+	Source         string `json:"source"` // Always "chain"
+	TotalCommitted int    `json:"total_committed"`
+	TotalRevealed  int    `json:"total_revealed"`
+}
+
+// chainPollCacheKey is the Redis key of a chain read on the request's
+// chain. It falls under cachePatterns, so a cache flush drops it too.
+func chainPollCacheKey(ctx context.Context, address database.Address) string {
+	return fmt.Sprintf("poll:%d:%s:chain", database.ChainFrom(ctx), address.Lower())
+}
+
+// pollFromChain returns a poll missing from the index as read from its
+// contract, reusing a recent read so that repeated requests for the same
+// address do not each cost a round of RPC calls. Addresses no factory
+// deployed are remembered as well, as null.
+func (h *PollHandler) pollFromChain(ctx context.Context, address database.Address) (*ChainPollResponse, error) {
+	cacheKey := chainPollCacheKey(ctx, address)
+	if h.redis != nil {
+		if cached, err := h.redis.Get(ctx, cacheKey).Result(); err == nil {
+			var response *ChainPollResponse
+			if json.Unmarshal([]byte(cached), &response) == nil {
+				return response, nil
+			}
+		}
+	}
+
+	response, err := h.readPollFromChain(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+
+	if h.redis != nil {
+		if data, err := json.Marshal(response); err == nil {
+			h.redis.Set(ctx, cacheKey, string(data), chainPollTTL)
+		}
+	}
+	return response, nil
+}
+
+// readPollFromChain reads a poll missing from the index from its contract
+// and queues it for indexing by the listener of the factory that deployed
+// it. It returns nil when the API has no node or factory configured for
+// the chain, or when none of the chain's factories deployed the poll.
+func (h *PollHandler) readPollFromChain(ctx context.Context, address database.Address) (*ChainPollResponse, error) {
+	chain := h.chain(ctx)
+	if chain.Client == nil {
+		return nil, nil
+	}
+
+	var chainPoll *blockchain.ChainPoll
+	for _, factory := range chain.Factories {
+		var err error
+		chainPoll, err = chain.Client.ReadPoll(ctx, factory, common.Address(address), chain.StartBlock(factory))
+		if err != nil {
+			return nil, err
+		}
+		if chainPoll != nil {
+			break
+		}
+	}
+	if chainPoll == nil {
+		return nil, nil
+	}
+	poll := chainPoll.Poll
+
+	// Reprocessing from the creation block indexes the poll even when the
+	// listener has already passed that block without storing it. One
+	// reindex per creation block is enough, so later reads queue nothing.
+	cursor, err := indexedThrough(ctx, h.db)
+	if err != nil {
+		return nil, err
+	}
+	cmd := &database.IndexerCommand{
+		Factory:     poll.Factory,
+		PollAddress: &address,
+		FromBlock:   poll.BlockNumber,
+		ToBlock:     max(int64(cursor), poll.BlockNumber),
+	}
+	if _, err := h.db.QueuePollReindex(ctx, cmd); err != nil {
+		return nil, err
+	}
+
+	return &ChainPollResponse{
+		Poll:           poll,
+		Source:         pollSourceChain,
+		TotalCommitted: chainPoll.Counters.TotalCommitted,
+		TotalRevealed:  chainPoll.Counters.TotalRevealed,
+	}, nil
+}
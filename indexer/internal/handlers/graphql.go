@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/gql"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"github.com/gofiber/fiber/v2"
+	graphql "github.com/graph-gophers/graphql-go"
+)
+
+// GraphQLHandler serves the GraphQL endpoint
+type GraphQLHandler struct {
+	db     *database.DB
+	schema *graphql.Schema
+	policy privacy.Policy
+}
+
+// NewGraphQLHandler creates a new GraphQL handler
+func NewGraphQLHandler(db *database.DB, policy privacy.Policy) (*GraphQLHandler, error) {
+	schema, err := gql.NewSchema(db)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GraphQLHandler{
+		db:     db,
+		schema: schema,
+		policy: policy,
+	}, nil
+}
+
+// GraphQLRequest is the body accepted by Query
+type GraphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// GraphQLError is one entry of GraphQLResponse.Errors
+type GraphQLError struct {
+	Message   string            `json:"message"`
+	Locations []GraphQLLocation `json:"locations,omitempty"`
+	Path      []any             `json:"path,omitempty"`
+}
+
+// GraphQLLocation points at the part of the query an error refers to
+type GraphQLLocation struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// GraphQLResponse documents the body returned by Query. Field errors are
+// reported in errors with a 200 status, per the GraphQL over HTTP convention.
+type GraphQLResponse struct {
+	Data   map[string]any `json:"data,omitempty"`
+	Errors []GraphQLError `json:"errors,omitempty"`
+}
+
+// Query executes a GraphQL query
+// POST /graphql
+func (h *GraphQLHandler) Query(c *fiber.Ctx) error {
+	var req GraphQLRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	if req.Query == "" {
+		return apierror.BadRequest(c, "query is required")
+	}
+
+	ctx := privacy.WithViewer(c.UserContext(), privacy.ViewerOf(c))
+	ctx = gql.WithLoaders(ctx, h.db, h.policy)
+	resp := h.schema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	return c.JSON(resp)
+}
@@ -0,0 +1,239 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// Check statuses reported by the health endpoints
+const (
+	CheckOK       = "ok"
+	CheckDegraded = "degraded"
+	CheckDown     = "down"
+	CheckDisabled = "disabled"
+)
+
+// checkTimeout bounds each dependency probe so readiness answers quickly
+const checkTimeout = 2 * time.Second
+
+// HealthHandler reports liveness, readiness and dependency health
+type HealthHandler struct {
+	db           *database.DB
+	redis        *redis.Client
+	maxLag       int64
+	maxHeartbeat time.Duration
+}
+
+// NewHealthHandler creates a new health handler.
+// Thresholds come from READY_MAX_LAG_BLOCKS (default 50) and
+// READY_MAX_HEARTBEAT_AGE (default 1m).
+func NewHealthHandler(db *database.DB, redis *redis.Client) *HealthHandler {
+	h := &HealthHandler{
+		db:           db,
+		redis:        redis,
+		maxLag:       50,
+		maxHeartbeat: time.Minute,
+	}
+
+	if v := os.Getenv("READY_MAX_LAG_BLOCKS"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n >= 0 {
+			h.maxLag = n
+		}
+	}
+	if v := os.Getenv("READY_MAX_HEARTBEAT_AGE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			h.maxHeartbeat = d
+		}
+	}
+
+	return h
+}
+
+// CheckResult is the outcome of probing one dependency
+type CheckResult struct {
+	Status   string `json:"status"`
+	Critical bool   `json:"critical"` // Whether a failure makes the service not ready
+	Error    string `json:"error,omitempty"`
+	Details  any    `json:"details,omitempty"`
+}
+
+// HealthReport is returned by Ready and Details
+type HealthReport struct {
+	Status string                 `json:"status"`
+	Time   string                 `json:"time"`
+	Checks map[string]CheckResult `json:"checks"`
+}
+
+// HealthResponse is returned by Health
+type HealthResponse struct {
+	Status string `json:"status"`
+	Time   string `json:"time"`
+}
+
+// PoolStats summarizes the Postgres connection pool
+type PoolStats struct {
+	TotalConns    int32 `json:"total_conns"`
+	IdleConns     int32 `json:"idle_conns"`
+	AcquiredConns int32 `json:"acquired_conns"`
+	MaxConns      int32 `json:"max_conns"`
+	AcquireCount  int64 `json:"acquire_count"`
+	EmptyAcquires int64 `json:"empty_acquire_count"`
+}
+
+// IndexerCheck describes the indexing progress of one listener
+type IndexerCheck struct {
+	*database.IndexerStatus
+	Lag          int64   `json:"lag_blocks"`
+	MaxLag       int64   `json:"max_lag_blocks"`
+	MaxHeartbeat float64 `json:"max_heartbeat_age_seconds"`
+}
+
+// Health reports that the process is up. It does not probe dependencies.
+// GET /health
+func (h *HealthHandler) Health(c *fiber.Ctx) error {
+	return c.JSON(HealthResponse{
+		Status: "ok",
+		Time:   time.Now().UTC().Format(time.RFC3339),
+	})
+}
+
+// Ready reports whether every critical dependency is healthy, with 503 otherwise
+// GET /ready
+func (h *HealthHandler) Ready(c *fiber.Ctx) error {
+	report := h.check(c.UserContext())
+
+	// Readiness only needs the verdict and the failing checks
+	for name, check := range report.Checks {
+		check.Details = nil
+		report.Checks[name] = check
+	}
+
+	return h.respond(c, report)
+}
+
+// Details reports every dependency check with pool stats and indexer lag
+// GET /health/details
+func (h *HealthHandler) Details(c *fiber.Ctx) error {
+	return h.respond(c, h.check(c.UserContext()))
+}
+
+func (h *HealthHandler) respond(c *fiber.Ctx, report *HealthReport) error {
+	if report.Status != CheckOK {
+		c.Status(fiber.StatusServiceUnavailable)
+	}
+	return c.JSON(report)
+}
+
+// check probes every dependency. The overall status is degraded if any
+// critical check is not ok.
+func (h *HealthHandler) check(ctx context.Context) *HealthReport {
+	report := &HealthReport{
+		Status: CheckOK,
+		Time:   time.Now().UTC().Format(time.RFC3339),
+		Checks: map[string]CheckResult{
+			"database": h.checkDatabase(ctx),
+			"redis":    h.checkRedis(ctx),
+			"indexer":  h.checkIndexer(ctx),
+		},
+	}
+
+	for _, check := range report.Checks {
+		if check.Critical && check.Status != CheckOK {
+			report.Status = CheckDegraded
+		}
+	}
+
+	return report
+}
+
+func (h *HealthHandler) checkDatabase(ctx context.Context) CheckResult {
+	stat := h.db.Pool.Stat()
+	result := CheckResult{
+		Status:   CheckOK,
+		Critical: true,
+		Details: PoolStats{
+			TotalConns:    stat.TotalConns(),
+			IdleConns:     stat.IdleConns(),
+			AcquiredConns: stat.AcquiredConns(),
+			MaxConns:      stat.MaxConns(),
+			AcquireCount:  stat.AcquireCount(),
+			EmptyAcquires: stat.EmptyAcquireCount(),
+		},
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := h.db.Pool.Ping(ctx); err != nil {
+		result.Status = CheckDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
+
+// checkRedis is not critical: the API serves uncached and rate limits in memory without Redis
+func (h *HealthHandler) checkRedis(ctx context.Context) CheckResult {
+	if h.redis == nil {
+		return CheckResult{Status: CheckDisabled}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	if err := h.redis.Ping(ctx).Err(); err != nil {
+		return CheckResult{Status: CheckDown, Error: err.Error()}
+	}
+	return CheckResult{Status: CheckOK}
+}
+
+func (h *HealthHandler) checkIndexer(ctx context.Context) CheckResult {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	statuses, err := h.db.ListIndexerStatuses(ctx)
+	if err != nil {
+		return CheckResult{Status: CheckDown, Critical: true, Error: err.Error()}
+	}
+	if len(statuses) == 0 {
+		return CheckResult{Status: CheckDown, Critical: true, Error: "no indexer heartbeat recorded"}
+	}
+
+	result := CheckResult{Status: CheckOK, Critical: true}
+	details := make([]IndexerCheck, 0, len(statuses))
+
+	for _, status := range statuses {
+		lag := status.Lag()
+		details = append(details, IndexerCheck{
+			IndexerStatus: status,
+			Lag:           lag,
+			MaxLag:        h.maxLag,
+			MaxHeartbeat:  h.maxHeartbeat.Seconds(),
+		})
+
+		switch {
+		case status.HeartbeatAge > h.maxHeartbeat.Seconds():
+			result.Status = CheckDown
+			result.Error = fmt.Sprintf("listener %s heartbeat is %.0fs old", status.ListenerID, status.HeartbeatAge)
+		case !status.Subscribed:
+			result.Status = CheckDown
+			result.Error = fmt.Sprintf("listener %s is not subscribed to new blocks", status.ListenerID)
+		case status.Paused && result.Status == CheckOK:
+			result.Status = CheckDegraded
+			result.Error = fmt.Sprintf("listener %s is paused by an operator", status.ListenerID)
+		case lag > h.maxLag && result.Status == CheckOK:
+			result.Status = CheckDegraded
+			result.Error = fmt.Sprintf("listener %s is %d blocks behind head", status.ListenerID, lag)
+		}
+	}
+
+	result.Details = details
+	return result
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ChainCheck compares a rebuilt poll with the contract read at the same block
+type ChainCheck struct {
+	*blockchain.PollCounters
+	Matches    bool     `json:"matches"`
+	Mismatches []string `json:"mismatches,omitempty"`
+	Error      string   `json:"error,omitempty"` // Set when the node could not serve state at the block
+}
+
+// HistoricalPollResponse is returned by GetPoll with at_block
+type HistoricalPollResponse struct {
+	*database.Poll
+	AtBlock    *blockchain.BlockRef `json:"at_block"`
+	ChainCheck *ChainCheck          `json:"chain_check,omitempty"`
+}
+
+// historicalPoll is a poll rebuilt at a past block
+type historicalPoll struct {
+	*blockchain.Snapshot
+	Block        *blockchain.BlockRef
+	Check        *ChainCheck
+	CurrentState string // State of the poll now, which governs vote redaction
+}
+
+// historyError is a request the events table cannot answer
+type historyError struct {
+	status  int
+	code    apierror.Code
+	message string
+}
+
+func (e *historyError) Error() string {
+	return e.message
+}
+
+// atBlock returns the at_block query parameter. The router has already
+// checked that it is a non-negative integer.
+func atBlock(c *fiber.Ctx) (uint64, bool) {
+	raw := c.Query("at_block")
+	if raw == "" {
+		return 0, false
+	}
+	block, err := strconv.ParseUint(raw, 10, 64)
+	return block, err == nil
+}
+
+// pollAt rebuilds a poll as of block from its stored events. The block's
+// hash and timestamp come from the node, and with verify the result is
+// cross-checked against the contract with eth_call pinned to the block.
+func (h *PollHandler) pollAt(ctx context.Context, address database.Address, block uint64, verify bool) (*historicalPoll, error) {
+	client := h.chain(ctx).Client
+	if client == nil {
+		return nil, &historyError{fiber.StatusServiceUnavailable, apierror.ChainUnavailable,
+			"historical queries need an Ethereum node for the chain; set RPC_URL or CHAINS for the API"}
+	}
+
+	poll, err := h.db.GetPollByAddress(ctx, address)
+	if err != nil {
+		return nil, err
+	}
+	if poll == nil {
+		return nil, &historyError{fiber.StatusNotFound, apierror.PollNotFound, "poll not found"}
+	}
+
+	cursor, err := indexedThrough(ctx, h.db)
+	if err != nil {
+		return nil, err
+	}
+	if block > cursor {
+		return nil, &historyError{fiber.StatusConflict, apierror.BlockNotIndexed,
+			fmt.Sprintf("block %d is past the indexer cursor (block %d)", block, cursor)}
+	}
+	if uint64(poll.BlockNumber) > block {
+		return nil, &historyError{fiber.StatusNotFound, apierror.PollNotFound,
+			fmt.Sprintf("poll was created at block %d, after block %d", poll.BlockNumber, block)}
+	}
+
+	ref, err := client.BlockAt(ctx, block)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := h.db.ListEventsUpToBlock(ctx, poll.ContractAddress, int64(block))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot, err := blockchain.Rebuild(poll, events, block)
+	if err != nil {
+		return nil, err
+	}
+
+	view := &historicalPoll{Snapshot: snapshot, Block: ref, CurrentState: poll.State}
+	if verify {
+		view.Check = &ChainCheck{}
+		counters, err := client.PollCountersAt(ctx, common.Address(poll.ContractAddress), block)
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return nil, err
+			}
+			view.Check.Error = err.Error()
+		} else {
+			view.Check.PollCounters = counters
+			view.Check.Mismatches = snapshot.Mismatches(counters)
+			view.Check.Matches = len(view.Check.Mismatches) == 0
+		}
+	}
+
+	return view, nil
+}
+
+// indexedThrough returns the highest block the indexer has processed on
+// the request's chain
+func indexedThrough(ctx context.Context, db *database.DB) (uint64, error) {
+	statuses, err := db.ListIndexerStatuses(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var cursor int64
+	var listeners int
+	for _, status := range statuses {
+		if status.ChainID == database.ChainFrom(ctx) {
+			cursor = max(cursor, status.LastBlock)
+			listeners++
+		}
+	}
+	if listeners == 0 {
+		if cursor, err = db.GetLastProcessedBlock(ctx); err != nil {
+			return 0, err
+		}
+	}
+
+	return uint64(cursor), nil
+}
+
+// historyFailed writes the response for an error returned by pollAt
+func historyFailed(c *fiber.Ctx, err error) error {
+	var he *historyError
+	if errors.As(err, &he) {
+		return apierror.Respond(c, he.status, he.code, he.message)
+	}
+	return apierror.InternalError(c, err, "failed to rebuild poll at block")
+}
@@ -0,0 +1,282 @@
+package handlers
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+	"github.com/redis/go-redis/v9"
+)
+
+// IndexerHandler handles operator control of the indexer. Requests are
+// written to control tables in Postgres, which the indexer polls. Every
+// request applies to the listeners of the chain it is scoped to.
+type IndexerHandler struct {
+	db     *database.DB
+	redis  *redis.Client
+	chains *blockchain.Chains
+}
+
+// NewIndexerHandler creates a new indexer control handler
+func NewIndexerHandler(db *database.DB, redis *redis.Client, chains *blockchain.Chains) *IndexerHandler {
+	return &IndexerHandler{
+		db:     db,
+		redis:  redis,
+		chains: chains,
+	}
+}
+
+// ListenerStatus is the progress of one listener
+type ListenerStatus struct {
+	*database.IndexerStatus
+	Lag int64 `json:"lag_blocks"`
+}
+
+// IndexerStatusResponse is returned by GetIndexerStatus, PauseIndexer and
+// ResumeIndexer.
+// Paused is the requested state; each listener reports when it has applied it.
+type IndexerStatusResponse struct {
+	Paused         bool                       `json:"paused"`
+	PauseUpdatedAt time.Time                  `json:"pause_updated_at"`
+	Listeners      []ListenerStatus           `json:"listeners"`
+	RecentCommands []*database.IndexerCommand `json:"recent_commands"`
+}
+
+// ReindexRequest is the body accepted by Reindex. Give either a block
+// range or a poll address; a poll is reindexed from its creation block up
+// to the indexer's cursor by the listener of the factory that deployed it.
+// A block range is reindexed for one factory, which may be omitted when
+// the chain has only one.
+type ReindexRequest struct {
+	FromBlock   *int64 `json:"from_block,omitempty"`
+	ToBlock     *int64 `json:"to_block,omitempty"`
+	PollAddress string `json:"poll_address,omitempty"`
+	Factory     string `json:"factory,omitempty"`
+}
+
+// FailedEventListResponse is returned by ListFailedEvents
+type FailedEventListResponse struct {
+	Events []*database.FailedEvent `json:"events"`
+	Count  int                     `json:"count"`
+}
+
+// FlushCacheResponse is returned by FlushCache
+type FlushCacheResponse struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// GetIndexerStatus reports the pause switch, listener progress and the
+// most recent reindex commands
+// GET /api/admin/indexer
+func (h *IndexerHandler) GetIndexerStatus(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	control, err := h.db.GetIndexerControl(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve indexer control")
+	}
+
+	return h.statusResponse(c, control)
+}
+
+// PauseIndexer stops the indexer from processing new blocks
+// POST /api/admin/indexer/pause
+func (h *IndexerHandler) PauseIndexer(c *fiber.Ctx) error {
+	return h.setPaused(c, true)
+}
+
+// ResumeIndexer restarts block processing; skipped blocks are caught up
+// POST /api/admin/indexer/resume
+func (h *IndexerHandler) ResumeIndexer(c *fiber.Ctx) error {
+	return h.setPaused(c, false)
+}
+
+func (h *IndexerHandler) setPaused(c *fiber.Ctx, paused bool) error {
+	control, err := h.db.SetIndexerPaused(c.UserContext(), paused)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to update indexer control")
+	}
+
+	return h.statusResponse(c, control)
+}
+
+func (h *IndexerHandler) statusResponse(c *fiber.Ctx, control *database.IndexerControl) error {
+	ctx := c.UserContext()
+
+	statuses, err := h.db.ListIndexerStatuses(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve indexer status")
+	}
+
+	commands, err := h.db.ListIndexerCommands(ctx, 10)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list indexer commands")
+	}
+
+	listeners := []ListenerStatus{}
+	for _, status := range statuses {
+		if status.ChainID == database.ChainFrom(ctx) {
+			listeners = append(listeners, ListenerStatus{IndexerStatus: status, Lag: status.Lag()})
+		}
+	}
+
+	return c.JSON(IndexerStatusResponse{
+		Paused:         control.Paused,
+		PauseUpdatedAt: control.UpdatedAt,
+		Listeners:      listeners,
+		RecentCommands: commands,
+	})
+}
+
+// Reindex queues a reindex of a block range or of one poll
+// POST /api/admin/indexer/reindex
+func (h *IndexerHandler) Reindex(c *fiber.Ctx) error {
+	var req ReindexRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	ctx := c.UserContext()
+	cmd := &database.IndexerCommand{Kind: database.CommandReindexRange}
+
+	switch {
+	case req.PollAddress != "" && (req.FromBlock != nil || req.ToBlock != nil):
+		return apierror.BadRequest(c, "give either a block range or poll_address")
+
+	case req.PollAddress != "" && req.Factory != "":
+		return apierror.BadRequest(c, "a poll is reindexed by the factory that deployed it; omit factory")
+
+	case req.PollAddress != "":
+		address, err := database.ParseAddress(req.PollAddress)
+		if err != nil {
+			return apierror.InvalidParam(c, apierror.InvalidAddress, "poll_address", err.Error())
+		}
+
+		poll, err := h.db.GetPollByAddress(ctx, address)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to retrieve poll")
+		}
+		if poll == nil {
+			return apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
+		}
+
+		cursor, err := h.db.GetLastProcessedBlock(ctx)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to retrieve indexer cursor")
+		}
+
+		cmd.Kind = database.CommandReindexPoll
+		cmd.Factory = poll.Factory
+		cmd.PollAddress = &address
+		cmd.FromBlock = poll.BlockNumber
+		cmd.ToBlock = max(cursor, poll.BlockNumber)
+
+		// Serve the reindexed poll rather than a cached copy
+		if h.redis != nil {
+			h.redis.Del(ctx, pollCacheKey(ctx, address))
+		}
+
+	case req.FromBlock != nil && req.ToBlock != nil:
+		if *req.FromBlock < 0 || *req.ToBlock < *req.FromBlock {
+			return apierror.BadRequest(c, "from_block must be non-negative and not after to_block")
+		}
+		cmd.FromBlock = *req.FromBlock
+		cmd.ToBlock = *req.ToBlock
+
+		if req.Factory != "" {
+			factory, err := database.ParseAddress(req.Factory)
+			if err != nil {
+				return apierror.InvalidParam(c, apierror.InvalidAddress, "factory", err.Error())
+			}
+			cmd.Factory = &factory
+		} else {
+			factory, err := soleFactory(h.chains.Get(database.ChainFrom(ctx)))
+			if err != nil {
+				return apierror.BadRequest(c, err.Error())
+			}
+			cmd.Factory = factory
+		}
+
+	default:
+		return apierror.BadRequest(c, "from_block and to_block, or poll_address, are required")
+	}
+
+	if err := h.db.CreateIndexerCommand(ctx, cmd); err != nil {
+		return apierror.InternalError(c, err, "failed to queue reindex")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(cmd)
+}
+
+// soleFactory returns the factory that reindexes a block range when none
+// is given. It returns nil when the API does not know the chain's
+// factories, which leaves the range to whichever of the chain's listeners
+// claims it.
+func soleFactory(chain *blockchain.Chain) (*database.Address, error) {
+	if chain == nil || len(chain.Factories) == 0 {
+		return nil, nil
+	}
+	if len(chain.Factories) > 1 {
+		return nil, fmt.Errorf("the chain has %d factories; give the factory to reindex", len(chain.Factories))
+	}
+	factory := database.Address(chain.Factories[0])
+	return &factory, nil
+}
+
+// ListFailedEvents lists events whose handler failed, in block order
+// GET /api/admin/indexer/failed-events
+func (h *IndexerHandler) ListFailedEvents(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+
+	events, err := h.db.ListFailedEvents(ctx, c.QueryBool("include_resolved", false), c.QueryInt("limit", 100))
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list failed events")
+	}
+
+	return c.JSON(FailedEventListResponse{
+		Events: events,
+		Count:  len(events),
+	})
+}
+
+// FlushCache deletes the chain's cached API responses from Redis
+// POST /api/admin/cache/flush
+func (h *IndexerHandler) FlushCache(c *fiber.Ctx) error {
+	if h.redis == nil {
+		return c.JSON(FlushCacheResponse{})
+	}
+
+	ctx := c.UserContext()
+	var deleted int64
+
+	for _, pattern := range cachePatterns(ctx) {
+		iter := h.redis.Scan(ctx, 0, pattern, 500).Iterator()
+		var keys []string
+		for iter.Next(ctx) {
+			keys = append(keys, iter.Val())
+			if len(keys) == 500 {
+				n, err := h.redis.Del(ctx, keys...).Result()
+				if err != nil {
+					return apierror.InternalError(c, err, "failed to flush cache")
+				}
+				deleted += n
+				keys = keys[:0]
+			}
+		}
+		if err := iter.Err(); err != nil {
+			return apierror.InternalError(c, err, "failed to scan cache")
+		}
+		if len(keys) > 0 {
+			n, err := h.redis.Del(ctx, keys...).Result()
+			if err != nil {
+				return apierror.InternalError(c, err, "failed to flush cache")
+			}
+			deleted += n
+		}
+	}
+
+	return c.JSON(FlushCacheResponse{Deleted: deleted})
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// LookupHandler finds indexed data by transaction hash or block number
+type LookupHandler struct {
+	db     *database.DB
+	policy privacy.Policy
+}
+
+// NewLookupHandler creates a new lookup handler
+func NewLookupHandler(db *database.DB, policy privacy.Policy) *LookupHandler {
+	return &LookupHandler{
+		db:     db,
+		policy: policy,
+	}
+}
+
+// DecodedEvent is an indexed event with its arguments decoded. Args is
+// omitted when the privacy policy withholds the event data.
+type DecodedEvent struct {
+	*database.Event
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// TransactionResponse is returned by GetTransaction
+type TransactionResponse struct {
+	TransactionHash string           `json:"transaction_hash"`
+	BlockNumber     int64            `json:"block_number"`
+	BlockHash       string           `json:"block_hash"`
+	GasUsed         *int64           `json:"gas_used,omitempty"` // Set when the indexer captured the receipt
+	Events          []*DecodedEvent  `json:"events"`
+	Polls           []*database.Poll `json:"polls"` // Polls the transaction created or emitted events from
+	Votes           []*database.Vote `json:"votes"` // Votes the transaction committed or revealed
+}
+
+// BlockEventsResponse is returned by GetBlockEvents
+type BlockEventsResponse struct {
+	BlockNumber int64           `json:"block_number"`
+	BlockHash   string          `json:"block_hash,omitempty"` // Omitted when no events were indexed in the block
+	Events      []*DecodedEvent `json:"events"`
+	Count       int             `json:"count"`
+}
+
+// GetTransaction returns the indexed events of a transaction with the
+// polls and votes it affected
+// GET /api/tx/:hash
+func (h *LookupHandler) GetTransaction(c *fiber.Ctx) error {
+	txHash, err := parseTxHash(c.Params("hash"))
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidTxHash, "hash", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	events, err := h.db.ListEventsByTransaction(ctx, txHash)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list transaction events")
+	}
+	if len(events) == 0 {
+		return apierror.NotFoundError(c, apierror.TxNotFound, "no indexed events in transaction")
+	}
+
+	decoded, err := h.decodeEvents(ctx, c, events)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to decode transaction events")
+	}
+
+	// Affected polls are the emitting contracts and any poll created here;
+	// revealed votes are keyed by the voter of each VoteRevealed event.
+	// Keys come from the stored events, which redaction does not touch.
+	seen := map[database.Address]bool{}
+	var pollAddresses, revealPolls, revealVoters []database.Address
+	addPoll := func(address database.Address) {
+		if !seen[address] {
+			seen[address] = true
+			pollAddresses = append(pollAddresses, address)
+		}
+	}
+	for _, event := range events {
+		if event.EventName != "PollCreated" {
+			addPoll(event.ContractAddress)
+		}
+		if event.EventName != "PollCreated" && event.EventName != "VoteRevealed" {
+			continue
+		}
+
+		args, err := blockchain.DecodeEvent(event)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to decode transaction events")
+		}
+		if address, ok := args["pollAddress"].(database.Address); ok {
+			addPoll(address)
+		}
+		if voter, ok := args["voter"].(database.Address); ok {
+			revealPolls = append(revealPolls, event.ContractAddress)
+			revealVoters = append(revealVoters, voter)
+		}
+	}
+
+	pollsByAddress, err := h.db.GetPollsByAddresses(ctx, pollAddresses)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve polls")
+	}
+	polls := make([]*database.Poll, 0, len(pollsByAddress))
+	for _, address := range pollAddresses {
+		if poll, ok := pollsByAddress[address]; ok {
+			polls = append(polls, poll)
+		}
+	}
+
+	votes, err := h.db.ListVotesByTransaction(ctx, txHash, revealPolls, revealVoters)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list transaction votes")
+	}
+	viewer := privacy.ViewerOf(c)
+	for i, vote := range votes {
+		state := ""
+		if poll, ok := pollsByAddress[vote.PollAddress]; ok {
+			state = poll.State
+		}
+		votes[i] = h.policy.Vote(vote, state, viewer)
+	}
+
+	first := events[0]
+	return c.JSON(TransactionResponse{
+		TransactionHash: first.TransactionHash,
+		BlockNumber:     first.BlockNumber,
+		BlockHash:       first.BlockHash,
+		GasUsed:         first.GasUsed,
+		Events:          decoded,
+		Polls:           polls,
+		Votes:           votes,
+	})
+}
+
+// GetBlockEvents returns every event indexed in a block
+// GET /api/blocks/:number
+func (h *LookupHandler) GetBlockEvents(c *fiber.Ctx) error {
+	number, err := strconv.ParseInt(c.Params("number"), 10, 64)
+	if err != nil || number < 0 {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "number", "block number must be a non-negative integer")
+	}
+
+	ctx := c.UserContext()
+
+	cursor, err := indexedThrough(ctx, h.db)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve indexer cursor")
+	}
+	if uint64(number) > cursor {
+		return apierror.Respond(c, fiber.StatusConflict, apierror.BlockNotIndexed,
+			fmt.Sprintf("block %d is past the indexer cursor (block %d)", number, cursor))
+	}
+
+	events, err := h.db.ListEventsByBlock(ctx, number)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list block events")
+	}
+
+	decoded, err := h.decodeEvents(ctx, c, events)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to decode block events")
+	}
+
+	resp := BlockEventsResponse{
+		BlockNumber: number,
+		Events:      decoded,
+		Count:       len(decoded),
+	}
+	if len(events) > 0 {
+		resp.BlockHash = events[0].BlockHash
+	}
+	return c.JSON(resp)
+}
+
+// decodeEvents redacts events for the caller and decodes what remains
+func (h *LookupHandler) decodeEvents(ctx context.Context, c *fiber.Ctx, events []*database.Event) ([]*DecodedEvent, error) {
+	states := map[database.Address]string{}
+	if h.policy.NeedsPollState() {
+		var addresses []database.Address
+		for _, event := range events {
+			addresses = append(addresses, event.ContractAddress)
+		}
+		polls, err := h.db.GetPollsByAddresses(ctx, addresses)
+		if err != nil {
+			return nil, err
+		}
+		for address, poll := range polls {
+			states[address] = poll.State
+		}
+	}
+
+	viewer := privacy.ViewerOf(c)
+	decoded := make([]*DecodedEvent, len(events))
+	for i, event := range events {
+		event = h.policy.Event(event, states[event.ContractAddress], viewer)
+		decoded[i] = &DecodedEvent{Event: event}
+		if event.EventData == "" {
+			continue
+		}
+
+		args, err := blockchain.DecodeEvent(event)
+		if err != nil {
+			return nil, err
+		}
+		decoded[i].Args = args
+	}
+	return decoded, nil
+}
+
+// parseTxHash validates a transaction hash and returns it in the form the
+// indexer stores
+func parseTxHash(raw string) (string, error) {
+	if len(raw) != 66 {
+		return "", fmt.Errorf("transaction hash must be 0x followed by 64 hex characters")
+	}
+	b, err := hexutil.Decode(raw)
+	if err != nil {
+		return "", fmt.Errorf("transaction hash must be 0x followed by 64 hex characters")
+	}
+	return common.BytesToHash(b).Hex(), nil
+}
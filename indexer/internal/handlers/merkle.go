@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/merkle"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gofiber/fiber/v2"
+)
+
+// MaxEligibleVoters caps the size of an uploaded voter list. At roughly 45
+// bytes per address this keeps requests under Fiber's default body limit.
+const MaxEligibleVoters = 50000
+
+// MerkleHandler handles voter eligibility tree requests
+type MerkleHandler struct {
+	db *database.DB
+}
+
+// NewMerkleHandler creates a new Merkle handler
+func NewMerkleHandler(db *database.DB) *MerkleHandler {
+	return &MerkleHandler{
+		db: db,
+	}
+}
+
+// RegisterVoterListRequest is the body accepted by RegisterVoterList
+type RegisterVoterListRequest struct {
+	Addresses []string `json:"addresses"`
+}
+
+// AddressIndexDetails points at the invalid entry of an uploaded address list
+type AddressIndexDetails struct {
+	Index int `json:"index"`
+}
+
+// RegisterVoterListResponse is returned by RegisterVoterList
+type RegisterVoterListResponse struct {
+	*database.MerkleTree
+	Created bool `json:"created"` // False when the same list was already registered
+}
+
+// RegisterVoterList builds the eligibility tree for a list of addresses and
+// stores it keyed by root. The root is what polls are created with.
+// POST /api/merkle
+func (h *MerkleHandler) RegisterVoterList(c *fiber.Ctx) error {
+	var req RegisterVoterListRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	if len(req.Addresses) == 0 {
+		return apierror.BadRequest(c, "addresses is required")
+	}
+	if len(req.Addresses) > MaxEligibleVoters {
+		return apierror.BadRequest(c, fmt.Sprintf("at most %d addresses are allowed", MaxEligibleVoters))
+	}
+
+	voters := make([]common.Address, len(req.Addresses))
+	for i, raw := range req.Addresses {
+		address, err := database.ParseAddress(raw)
+		if err != nil {
+			return apierror.RespondDetails(c, fiber.StatusBadRequest, apierror.InvalidAddress,
+				fmt.Sprintf("invalid address at index %d", i), AddressIndexDetails{Index: i})
+		}
+		voters[i] = common.Address(address)
+	}
+
+	tree, err := merkle.Build(voters)
+	if err != nil {
+		return apierror.BadRequest(c, err.Error())
+	}
+
+	root := tree.Root().Hex()
+	proofs := make([]*database.MerkleProof, 0, tree.Size())
+	for _, voter := range tree.Voters() {
+		path, _ := tree.Proof(voter)
+		proof := &database.MerkleProof{
+			Root:  root,
+			Voter: database.Address(voter),
+			Leaf:  merkle.Leaf(voter).Hex(),
+			Proof: make([]string, len(path)),
+		}
+		for i, sibling := range path {
+			proof.Proof[i] = sibling.Hex()
+		}
+		proofs = append(proofs, proof)
+	}
+
+	stored := &database.MerkleTree{Root: root, EligibleCount: tree.Size()}
+	created, err := h.db.CreateMerkleTree(c.UserContext(), stored, proofs)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to store merkle tree")
+	}
+
+	status := fiber.StatusOK
+	if created {
+		status = fiber.StatusCreated
+	}
+
+	return c.Status(status).JSON(RegisterVoterListResponse{
+		MerkleTree: stored,
+		Created:    created,
+	})
+}
+
+// GetMerkleTree retrieves a registered tree
+// GET /api/merkle/:root
+func (h *MerkleHandler) GetMerkleTree(c *fiber.Ctx) error {
+	root, err := parseMerkleRoot(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "root", err.Error())
+	}
+
+	tree, err := h.db.GetMerkleTree(c.UserContext(), root)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve merkle tree")
+	}
+
+	if tree == nil {
+		return apierror.NotFoundError(c, apierror.MerkleTreeNotFound, "merkle tree not found")
+	}
+
+	return c.JSON(tree)
+}
+
+// GetMerkleProof returns the proof a voter passes to commitVote
+// GET /api/merkle/:root/proof/:voter
+func (h *MerkleHandler) GetMerkleProof(c *fiber.Ctx) error {
+	root, err := parseMerkleRoot(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "root", err.Error())
+	}
+
+	voter, err := database.ParseAddress(c.Params("voter"))
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "voter", "invalid voter address")
+	}
+
+	ctx := c.UserContext()
+
+	proof, err := h.db.GetMerkleProof(ctx, root, voter)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve merkle proof")
+	}
+
+	if proof == nil {
+		// Distinguish an unknown root from an ineligible voter
+		tree, err := h.db.GetMerkleTree(ctx, root)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to retrieve merkle tree")
+		}
+
+		if tree == nil {
+			return apierror.NotFoundError(c, apierror.MerkleTreeNotFound, "merkle tree not found")
+		}
+		return apierror.NotFoundError(c, apierror.VoterNotEligible, "voter is not eligible")
+	}
+
+	return c.JSON(proof)
+}
+
+// parseMerkleRoot validates the :root route parameter and returns it in the
+// lowercase form polls store
+func parseMerkleRoot(c *fiber.Ctx) (string, error) {
+	root, err := hexutil.Decode(c.Params("root"))
+	if err != nil || len(root) != common.HashLength {
+		return "", errors.New("invalid merkle root")
+	}
+	return common.BytesToHash(root).Hex(), nil
+}
@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Metrics records request counts and latency per route template.
+// Route templates (e.g. /api/polls/:address) keep label cardinality bounded.
+func Metrics(m *instrumentation.API) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		err := c.Next()
+
+		status := c.Response().StatusCode()
+		if err != nil {
+			if fe, ok := err.(*fiber.Error); ok {
+				status = fe.Code
+			} else {
+				status = fiber.StatusInternalServerError
+			}
+		}
+
+		route := c.Route().Path
+		if status == fiber.StatusNotFound && route == "/" {
+			// Unmatched paths all share one label value
+			route = "unmatched"
+		}
+
+		m.Requests.WithLabelValues(route, c.Method(), strconv.Itoa(status)).Inc()
+		m.RequestDuration.WithLabelValues(route, c.Method()).Observe(time.Since(start).Seconds())
+
+		return err
+	}
+}
@@ -0,0 +1,500 @@
+package handlers
+
+import (
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/audit"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/openapi"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Poll states accepted by the state filter
+var pollStates = []string{"active", "closed", "tallied"}
+
+// pollStatuses are the derived statuses accepted by the status filter
+var pollStatuses = []string{
+	database.StatusActive, database.StatusOverdue, database.StatusClosed, database.StatusTallied,
+}
+
+var pollAddressParam = openapi.PathAddress("address", "Poll contract address (any letter case)")
+
+// ChainIDParam is the chain of routes under /api/chains/{chainId}. The same
+// routes without it serve the default chain.
+var ChainIDParam = openapi.Parameter{
+	Name:        "chainId",
+	In:          "path",
+	Description: "Chain ID; the same route without /chains/{chainId} serves the default chain",
+	Required:    true,
+	Schema:      &openapi.Schema{Type: "integer", Format: "int64"},
+}
+
+// Historical query parameters shared by the poll, stats and votes routes
+var (
+	atBlockParam = openapi.QueryBlock("at_block", "Rebuild the response from indexed events as of this block")
+	verifyParam  = openapi.QueryBool("verify", "With at_block, cross-check against the contract using eth_call at that block", false)
+)
+
+// historyErrors are the extra statuses an at_block query can return
+var historyErrors = []int{fiber.StatusConflict, fiber.StatusServiceUnavailable}
+
+var merkleRootParam = openapi.Parameter{
+	Name:        "root",
+	In:          "path",
+	Description: "Voter Merkle root as 0x-prefixed hex",
+	Required:    true,
+	Schema:      &openapi.Schema{Type: "string", Pattern: "^0x[0-9a-fA-F]{64}$"},
+}
+
+// Operation definitions for the poll routes. Query parameters listed here
+// are validated before the handler runs, so handlers can trust their shape.
+var (
+	ListPollsOp = &openapi.Operation{
+		ID:      "listPolls",
+		Summary: "List indexed polls",
+		Tags:    []string{"polls"},
+		Params: []openapi.Parameter{
+			openapi.QueryEnum("state", "Filter by poll state", pollStates...),
+			openapi.QueryEnum("status", "Filter by derived status; overdue is an active poll past its closing time", pollStatuses...),
+			openapi.QueryString("tag", "Only polls annotated with this tag"),
+			openapi.QueryInt("limit", "Maximum number of polls to return", 20, 1, 100),
+			openapi.QueryInt("offset", "Number of polls to skip", 0, 0, 1<<31-1),
+		},
+		Response: PollListResponse{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ListOverduePollsOp = &openapi.Operation{
+		ID:      "listOverduePolls",
+		Summary: "List active polls past their closing time, longest overdue first",
+		Tags:    []string{"polls"},
+		Params: []openapi.Parameter{
+			openapi.QueryInt("min_seconds", "Only polls overdue by at least this many seconds", 0, 0, 1<<31-1),
+			openapi.QueryInt("limit", "Maximum number of polls to return", 100, 1, 500),
+		},
+		Response: OverduePollListResponse{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetPollsBatchOp = &openapi.Operation{
+		ID:       "getPollsBatch",
+		Summary:  "Get up to 100 polls with their vote counts and results; unknown addresses are reported per item",
+		Tags:     []string{"polls"},
+		Body:     BatchPollRequest{},
+		Response: BatchPollResponse{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetPollOp = &openapi.Operation{
+		ID:          "getPoll",
+		Summary:     "Get a poll by contract address, optionally as of a past block; polls not indexed yet are read from the chain",
+		Tags:        []string{"polls"},
+		Params:      []openapi.Parameter{pollAddressParam, atBlockParam, verifyParam},
+		Response:    openapi.OneOf(database.Poll{}, HistoricalPollResponse{}, ChainPollResponse{}),
+		Errors:      append([]int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError}, historyErrors...),
+		Conditional: true,
+	}
+
+	GetPollVotesOp = &openapi.Operation{
+		ID:      "getPollVotes",
+		Summary: "List vote commitments for a poll, optionally as of a past block",
+		Tags:    []string{"votes"},
+		Params: []openapi.Parameter{
+			pollAddressParam,
+			openapi.QueryBool("revealed_only", "Only return revealed votes", false),
+			atBlockParam,
+			verifyParam,
+		},
+		Response:    VoteListResponse{},
+		Errors:      append([]int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError}, historyErrors...),
+		Conditional: true,
+	}
+
+	GetPollResultsOp = &openapi.Operation{
+		ID:      "getPollResults",
+		Summary: "Get tallied results, or a pending or provisional summary before tally",
+		Tags:    []string{"results"},
+		Params: []openapi.Parameter{
+			pollAddressParam,
+			openapi.QueryBool("provisional", "Before tally, return the running count of revealed votes", false),
+		},
+		Response:    openapi.OneOf(database.Result{}, PendingResultsResponse{}, ProvisionalResultsResponse{}),
+		Errors:      []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+		Conditional: true,
+	}
+
+	GetPollAnalyticsOp = &openapi.Operation{
+		ID:       "getPollAnalytics",
+		Summary:  "Get option percentages, winners, reveal rate and margin for a closed or tallied poll",
+		Tags:     []string{"results"},
+		Params:   []openapi.Parameter{pollAddressParam},
+		Response: PollAnalyticsResponse{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusConflict, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetPollTimeseriesOp = &openapi.Operation{
+		ID:      "getPollTimeseries",
+		Summary: "Get commit and reveal counts per minute, hour or block",
+		Tags:    []string{"votes"},
+		Params: []openapi.Parameter{
+			pollAddressParam,
+			openapi.QueryEnum("bucket", "Bucket size (default 1h)", database.BucketMinute, database.BucketHour, database.BucketBlock),
+		},
+		Response: TimeseriesResponse{},
+		Timeout:  8 * time.Second,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ExportPollOp = &openapi.Operation{
+		ID:      "exportPoll",
+		Summary: "Download the poll audit bundle: metadata, events, votes, results, gas and consistency checks",
+		Tags:    []string{"audit"},
+		Params: []openapi.Parameter{
+			pollAddressParam,
+			openapi.QueryEnum("format", "Export format (default json)", audit.FormatJSON, audit.FormatCSV, audit.FormatZIP),
+		},
+		Response: audit.Bundle{},
+		Produces: []string{"text/csv", "application/zip"},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetVoteCountOp = &openapi.Operation{
+		ID:          "getPollStats",
+		Summary:     "Get commit and reveal counts for a poll, optionally as of a past block",
+		Tags:        []string{"votes"},
+		Params:      []openapi.Parameter{pollAddressParam, atBlockParam, verifyParam},
+		Response:    VoteStatsResponse{},
+		Errors:      append([]int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError}, historyErrors...),
+		Conditional: true,
+	}
+)
+
+// Operation definitions for the poll annotation routes. Reading an
+// annotation needs no more than the poll routes; changing one requires the
+// annotate scope.
+var (
+	GetPollAnnotationOp = &openapi.Operation{
+		ID:       "getPollAnnotation",
+		Summary:  "Get the tags, notes and scenario ID attached to a poll",
+		Tags:     []string{"annotations"},
+		Params:   []openapi.Parameter{pollAddressParam},
+		Response: database.PollAnnotation{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	SetPollAnnotationOp = &openapi.Operation{
+		ID:       "setPollAnnotation",
+		Summary:  "Replace the tags, notes and scenario ID attached to a poll",
+		Tags:     []string{"annotations"},
+		Params:   []openapi.Parameter{pollAddressParam},
+		Body:     AnnotationRequest{},
+		Response: database.PollAnnotation{},
+		Scope:    auth.ScopeAnnotate,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	DeletePollAnnotationOp = &openapi.Operation{
+		ID:      "deletePollAnnotation",
+		Summary: "Remove the tags, notes and scenario ID attached to a poll",
+		Tags:    []string{"annotations"},
+		Params:  []openapi.Parameter{pollAddressParam},
+		Status:  fiber.StatusNoContent,
+		Scope:   auth.ScopeAnnotate,
+		Errors:  []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	AddPollTagsOp = &openapi.Operation{
+		ID:       "addPollTags",
+		Summary:  "Add tags to a poll, keeping its other tags",
+		Tags:     []string{"annotations"},
+		Params:   []openapi.Parameter{pollAddressParam},
+		Body:     AddTagsRequest{},
+		Response: database.PollAnnotation{},
+		Scope:    auth.ScopeAnnotate,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	RemovePollTagOp = &openapi.Operation{
+		ID:      "removePollTag",
+		Summary: "Remove one tag from a poll",
+		Tags:    []string{"annotations"},
+		Params: []openapi.Parameter{
+			pollAddressParam,
+			openapi.PathString("tag", "Tag to remove"),
+		},
+		Response: database.PollAnnotation{},
+		Scope:    auth.ScopeAnnotate,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// Operation definitions for the transaction and block lookup routes
+var (
+	GetTransactionOp = &openapi.Operation{
+		ID:       "getTransaction",
+		Summary:  "Get the indexed events of a transaction with the polls and votes it affected",
+		Tags:     []string{"lookup"},
+		Params:   []openapi.Parameter{openapi.PathHash("hash", "Transaction hash (any letter case)")},
+		Response: TransactionResponse{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetBlockEventsOp = &openapi.Operation{
+		ID:       "getBlockEvents",
+		Summary:  "List the events indexed in a block",
+		Tags:     []string{"lookup"},
+		Params:   []openapi.Parameter{openapi.PathBlock("number", "Block number")},
+		Response: BlockEventsResponse{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusConflict, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// ListChainsOp documents the list of served chains
+var ListChainsOp = &openapi.Operation{
+	ID:       "listChains",
+	Summary:  "List the chains served under /api/chains/{chainId}",
+	Tags:     []string{"chains"},
+	Response: ChainListResponse{},
+	Errors:   []int{fiber.StatusTooManyRequests},
+}
+
+// Operation definitions for the platform statistics routes
+var (
+	GetPlatformStatsOp = &openapi.Operation{
+		ID:      "getPlatformStats",
+		Summary: "Get poll and vote statistics for the chain",
+		Tags:    []string{"stats"},
+		Params: []openapi.Parameter{
+			openapi.QueryInt("top", "Number of most active creators and voters to return", 10, 0, 100),
+		},
+		Response: database.PlatformStats{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// Operation definitions for the voter eligibility routes
+var (
+	RegisterVoterListOp = &openapi.Operation{
+		ID:       "registerVoterList",
+		Summary:  "Build and store the voter eligibility tree for a list of addresses",
+		Tags:     []string{"merkle"},
+		Body:     RegisterVoterListRequest{},
+		Response: RegisterVoterListResponse{},
+		Status:   fiber.StatusCreated,
+		Timeout:  8 * time.Second,
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetMerkleTreeOp = &openapi.Operation{
+		ID:       "getMerkleTree",
+		Summary:  "Get a registered voter eligibility tree",
+		Tags:     []string{"merkle"},
+		Params:   []openapi.Parameter{merkleRootParam},
+		Response: database.MerkleTree{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	GetMerkleProofOp = &openapi.Operation{
+		ID:      "getMerkleProof",
+		Summary: "Get the Merkle proof a voter passes to commitVote",
+		Tags:    []string{"merkle"},
+		Params: []openapi.Parameter{
+			merkleRootParam,
+			openapi.PathAddress("voter", "Voter address (any letter case)"),
+		},
+		Response: database.MerkleProof{},
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// Operation definitions for the admin routes
+var (
+	CreateAPIKeyOp = &openapi.Operation{
+		ID:       "createApiKey",
+		Summary:  "Issue a new API key; the plaintext key is only returned once",
+		Tags:     []string{"admin"},
+		Body:     CreateAPIKeyRequest{},
+		Response: CreateAPIKeyResponse{},
+		Status:   fiber.StatusCreated,
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ListAPIKeysOp = &openapi.Operation{
+		ID:       "listApiKeys",
+		Summary:  "List API keys without their secrets",
+		Tags:     []string{"admin"},
+		Response: APIKeyListResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	RevokeAPIKeyOp = &openapi.Operation{
+		ID:      "revokeApiKey",
+		Summary: "Revoke an API key",
+		Tags:    []string{"admin"},
+		Params:  []openapi.Parameter{openapi.PathString("id", "API key ID")},
+		Status:  fiber.StatusNoContent,
+		Scope:   auth.ScopeAdmin,
+		Errors:  []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// Operation definitions for the indexer control routes
+var (
+	GetIndexerStatusOp = &openapi.Operation{
+		ID:       "getIndexerStatus",
+		Summary:  "Get the pause switch, listener cursor, head, lag and subscription state, and recent reindex commands",
+		Tags:     []string{"admin"},
+		Response: IndexerStatusResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	PauseIndexerOp = &openapi.Operation{
+		ID:       "pauseIndexer",
+		Summary:  "Stop processing new blocks until resumed",
+		Tags:     []string{"admin"},
+		Response: IndexerStatusResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ResumeIndexerOp = &openapi.Operation{
+		ID:       "resumeIndexer",
+		Summary:  "Resume processing blocks, catching up on those skipped while paused",
+		Tags:     []string{"admin"},
+		Response: IndexerStatusResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ReindexOp = &openapi.Operation{
+		ID:       "reindex",
+		Summary:  "Queue a reindex of a block range or of one poll",
+		Tags:     []string{"admin"},
+		Body:     ReindexRequest{},
+		Response: database.IndexerCommand{},
+		Status:   fiber.StatusAccepted,
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ListFailedEventsOp = &openapi.Operation{
+		ID:      "listFailedEvents",
+		Summary: "List events whose handler failed, in chain order",
+		Tags:    []string{"admin"},
+		Params: []openapi.Parameter{
+			openapi.QueryBool("include_resolved", "Include events a reindex has since processed", false),
+			openapi.QueryInt("limit", "Maximum number of events to return", 100, 1, 1000),
+		},
+		Response: FailedEventListResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	FlushCacheOp = &openapi.Operation{
+		ID:       "flushCache",
+		Summary:  "Delete the chain's cached API responses from Redis",
+		Tags:     []string{"admin"},
+		Response: FlushCacheResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// Operation definitions for the webhook routes
+var (
+	CreateWebhookOp = &openapi.Operation{
+		ID:       "createWebhook",
+		Summary:  "Subscribe a URL to poll lifecycle events; the signing secret is only returned once",
+		Tags:     []string{"webhooks"},
+		Body:     CreateWebhookRequest{},
+		Response: CreateWebhookResponse{},
+		Status:   fiber.StatusCreated,
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ListWebhooksOp = &openapi.Operation{
+		ID:       "listWebhooks",
+		Summary:  "List webhook subscriptions without their secrets",
+		Tags:     []string{"webhooks"},
+		Response: WebhookListResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	DeleteWebhookOp = &openapi.Operation{
+		ID:      "deleteWebhook",
+		Summary: "Delete a webhook subscription and its delivery log",
+		Tags:    []string{"webhooks"},
+		Params:  []openapi.Parameter{openapi.PathString("id", "Webhook ID")},
+		Status:  fiber.StatusNoContent,
+		Scope:   auth.ScopeAdmin,
+		Errors:  []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ListWebhookDeliveriesOp = &openapi.Operation{
+		ID:      "listWebhookDeliveries",
+		Summary: "List recent deliveries of a webhook, newest first",
+		Tags:    []string{"webhooks"},
+		Params: []openapi.Parameter{
+			openapi.PathString("id", "Webhook ID"),
+			openapi.QueryInt("limit", "Maximum number of deliveries to return", 50, 1, 500),
+		},
+		Response: WebhookDeliveryListResponse{},
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+
+	ReplayWebhookDeliveryOp = &openapi.Operation{
+		ID:       "replayWebhookDelivery",
+		Summary:  "Queue a delivery to be sent again with its original payload",
+		Tags:     []string{"webhooks"},
+		Params:   []openapi.Parameter{openapi.PathString("id", "Delivery ID")},
+		Response: database.WebhookDelivery{},
+		Status:   fiber.StatusAccepted,
+		Scope:    auth.ScopeAdmin,
+		Errors:   []int{fiber.StatusBadRequest, fiber.StatusUnauthorized, fiber.StatusForbidden, fiber.StatusNotFound, fiber.StatusTooManyRequests, fiber.StatusInternalServerError},
+	}
+)
+
+// Operation definitions for the health routes. Ready and HealthDetails
+// return the same report body with 503 when a critical check fails.
+var (
+	HealthOp = &openapi.Operation{
+		ID:       "health",
+		Summary:  "Liveness check; does not probe dependencies",
+		Tags:     []string{"health"},
+		Response: HealthResponse{},
+	}
+
+	ReadyOp = &openapi.Operation{
+		ID:       "ready",
+		Summary:  "Readiness check; 503 when the database or indexer is unhealthy",
+		Tags:     []string{"health"},
+		Response: HealthReport{},
+	}
+
+	HealthDetailsOp = &openapi.Operation{
+		ID:       "healthDetails",
+		Summary:  "Dependency health with pool stats and indexer lag",
+		Tags:     []string{"health"},
+		Response: HealthReport{},
+	}
+)
+
+// GraphQLOp documents the GraphQL endpoint. The schema itself is
+// introspectable through the endpoint.
+var GraphQLOp = &openapi.Operation{
+	ID:       "graphql",
+	Summary:  "Query polls, votes, results and events with GraphQL",
+	Tags:     []string{"graphql"},
+	Body:     GraphQLRequest{},
+	Response: GraphQLResponse{},
+	Timeout:  8 * time.Second,
+	Errors:   []int{fiber.StatusBadRequest, fiber.StatusTooManyRequests},
+}
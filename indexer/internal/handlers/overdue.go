@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"context"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Sources of the chain time used to derive poll status
+const (
+	chainTimeHead      = "chain_head" // Timestamp of the head block seen by the indexer
+	chainTimeWallClock = "wall_clock" // No head reported yet
+)
+
+// OverduePollListResponse is returned by ListOverduePolls
+type OverduePollListResponse struct {
+	Polls           []*database.Poll `json:"polls"`
+	Count           int              `json:"count"`
+	ChainTime       time.Time        `json:"chain_time"`
+	ChainTimeSource string           `json:"chain_time_source"`
+}
+
+// chainTime returns the time deadlines are judged against: the timestamp
+// of the chain head last reported by the indexer, or the wall clock
+// before one has been reported
+func (h *PollHandler) chainTime(ctx context.Context) (time.Time, string, error) {
+	headTime, err := h.db.GetChainTime(ctx)
+	if err != nil {
+		return time.Time{}, "", err
+	}
+	if headTime == nil {
+		return time.Now().UTC(), chainTimeWallClock, nil
+	}
+	return *headTime, chainTimeHead, nil
+}
+
+// ListOverduePolls lists polls still active on chain after their closing
+// time, longest overdue first. A poll shows up here when the oracle does
+// not close it on time.
+// GET /api/polls/overdue?min_seconds=0&limit=100
+func (h *PollHandler) ListOverduePolls(c *fiber.Ctx) error {
+	minOverdue := time.Duration(c.QueryInt("min_seconds", 0)) * time.Second
+	limit := c.QueryInt("limit", 100)
+
+	ctx := c.UserContext()
+
+	now, source, err := h.chainTime(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get chain time")
+	}
+
+	polls, err := h.db.ListOverduePolls(ctx, now, minOverdue, limit)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list overdue polls")
+	}
+
+	for _, poll := range polls {
+		poll.SetChainTime(now)
+	}
+
+	return c.JSON(OverduePollListResponse{
+		Polls:           polls,
+		Count:           len(polls),
+		ChainTime:       now,
+		ChainTimeSource: source,
+	})
+}
@@ -3,67 +3,223 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"strings"
 	"time"
 
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/analytics"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/blockchain"
 	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/privacy"
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
 	"github.com/gofiber/fiber/v2"
 	"github.com/redis/go-redis/v9"
 )
 
 // PollHandler handles poll-related HTTP requests
 type PollHandler struct {
-	db    *database.DB
-	redis *redis.Client
+	db      *database.DB
+	redis   *redis.Client
+	chains  *blockchain.Chains
+	metrics *instrumentation.API
+	policy  privacy.Policy
+}
+
+// PollListResponse is returned by ListPolls
+type PollListResponse struct {
+	Polls  []*database.Poll `json:"polls"`
+	Limit  int              `json:"limit"`
+	Offset int              `json:"offset"`
+	Count  int              `json:"count"`
+}
+
+// VoteListResponse is returned by GetPollVotes. AtBlock and ChainCheck
+// are set for at_block queries.
+type VoteListResponse struct {
+	Votes      []*database.Vote     `json:"votes"`
+	Count      int                  `json:"count"`
+	AtBlock    *blockchain.BlockRef `json:"at_block,omitempty"`
+	ChainCheck *ChainCheck          `json:"chain_check,omitempty"`
+}
+
+// PendingResultsResponse is returned by GetPollResults before a poll is tallied
+type PendingResultsResponse struct {
+	Status     string `json:"status"`
+	PollState  string `json:"poll_state"`
+	TotalVotes int    `json:"total_votes"`
+	Message    string `json:"message"`
+}
+
+// ProvisionalResultsResponse is returned by GetPollResults with provisional=true
+// before a poll is tallied. Counts come from indexed reveals and are not final.
+type ProvisionalResultsResponse struct {
+	Status        string     `json:"status"`
+	Provisional   bool       `json:"provisional"`
+	PollState     string     `json:"poll_state"`
+	VoteCounts    []int      `json:"vote_counts"`
+	RevealedVotes int        `json:"revealed_votes"`
+	TotalVotes    int        `json:"total_votes"`
+	LastBlock     int64      `json:"last_block"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+	Message       string     `json:"message"`
+}
+
+// VoteStatsResponse is returned by GetVoteCount
+type VoteStatsResponse struct {
+	PollAddress    database.Address `json:"poll_address"`
+	TotalVotes     int              `json:"total_votes"`
+	RevealedVotes  int              `json:"revealed_votes"`
+	PendingReveals int              `json:"pending_reveals"`
+	// EligibleVoters and Turnout are set when the poll's voterMerkleRoot
+	// has been registered. Turnout is committed votes over eligible voters, 0-1.
+	EligibleVoters *int     `json:"eligible_voters,omitempty"`
+	Turnout        *float64 `json:"turnout,omitempty"`
+	// Set for at_block queries
+	AtBlock    *blockchain.BlockRef `json:"at_block,omitempty"`
+	ChainCheck *ChainCheck          `json:"chain_check,omitempty"`
+}
+
+// PollAnalyticsResponse is returned by GetPollAnalytics
+type PollAnalyticsResponse struct {
+	PollAddress database.Address `json:"poll_address"`
+	PollState   string           `json:"poll_state"`
+	// Source is "tally" once results are tallied, otherwise "reveals"
+	Source string `json:"source"`
+	*analytics.Summary
+}
+
+// TimeseriesMarkers locates the end of the commit phase on the timeseries
+type TimeseriesMarkers struct {
+	ClosesAt   time.Time `json:"closes_at"`             // Scheduled end of the commit phase
+	CloseBlock *int64    `json:"close_block,omitempty"` // Block of the PollClosed event, once indexed
+}
+
+// TimeseriesResponse is returned by GetPollTimeseries
+type TimeseriesResponse struct {
+	PollAddress database.Address             `json:"poll_address"`
+	Bucket      string                       `json:"bucket"`
+	Buckets     []*database.TimeseriesBucket `json:"buckets"`
+	Markers     TimeseriesMarkers            `json:"markers"`
 }
 
 // NewPollHandler creates a new poll handler
-func NewPollHandler(db *database.DB, redis *redis.Client) *PollHandler {
+func NewPollHandler(db *database.DB, redis *redis.Client, chains *blockchain.Chains, metrics *instrumentation.API, policy privacy.Policy) *PollHandler {
 	return &PollHandler{
-		db:    db,
-		redis: redis,
+		db:      db,
+		redis:   redis,
+		chains:  chains,
+		metrics: metrics,
+		policy:  policy,
 	}
 }
 
-// GetPoll retrieves a poll by contract address
-// GET /api/polls/:address
+// pollCacheKey is the Redis key of a cached poll on the request's chain
+func pollCacheKey(ctx context.Context, address database.Address) string {
+	return fmt.Sprintf("poll:%d:%s", database.ChainFrom(ctx), address.Lower())
+}
+
+// cachePatterns are the Redis key patterns holding the cached API
+// responses of the request's chain. Rate limit buckets share the instance
+// and are left alone.
+func cachePatterns(ctx context.Context) []string {
+	return []string{fmt.Sprintf("poll:%d:*", database.ChainFrom(ctx))}
+}
+
+// chain returns the chain a request is scoped to. Requests only reach a
+// handler for a chain the API serves.
+func (h *PollHandler) chain(ctx context.Context) *blockchain.Chain {
+	if chain := h.chains.Get(database.ChainFrom(ctx)); chain != nil {
+		return chain
+	}
+	return &blockchain.Chain{ID: database.ChainFrom(ctx)}
+}
+
+// GetPoll retrieves a poll by contract address. With at_block the poll is
+// rebuilt from its events as of that block. A poll the indexer has not
+// stored yet is read from the chain, when a node and factory are
+// configured, and queued for indexing.
+// GET /api/polls/:address?at_block=
 func (h *PollHandler) GetPoll(c *fiber.Ctx) error {
-	address := c.Params("address")
-	if address == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "poll address is required",
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	// Historical queries bypass the cache
+	if block, ok := atBlock(c); ok {
+		view, err := h.pollAt(ctx, address, block, c.QueryBool("verify", false))
+		if err != nil {
+			return historyFailed(c, err)
+		}
+		view.Poll.SetChainTime(time.Unix(view.Block.Timestamp, 0).UTC())
+
+		return c.JSON(HistoricalPollResponse{
+			Poll:       view.Poll,
+			AtBlock:    view.Block,
+			ChainCheck: view.Check,
 		})
 	}
 
-	ctx := context.Background()
+	// Status is derived at read time, so cached polls stay current
+	now, _, err := h.chainTime(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get chain time")
+	}
+
+	// Revalidations are answered from the poll's version alone
+	version, err := h.db.GetPollVersion(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+	cond := pollConditional(version, now)
+	if cond.fresh(c) {
+		return cond.notModified(c)
+	}
 
 	// Try to get from cache first
-	cacheKey := "poll:" + address
+	cacheKey := pollCacheKey(ctx, address)
 	var poll *database.Poll
 
-	// Check Redis cache
+	// Check Redis cache. The indexer does not evict polls whose state it
+	// changes, so an entry older than the indexed state is skipped.
 	if h.redis != nil {
 		cached, err := h.redis.Get(ctx, cacheKey).Result()
 		if err == nil && cached != "" {
 			var cachedPoll database.Poll
-			if json.Unmarshal([]byte(cached), &cachedPoll) == nil {
+			if json.Unmarshal([]byte(cached), &cachedPoll) == nil && (version == nil || cachedPoll.State == version.State) {
+				h.metrics.CacheHit("poll", true)
+				cachedPoll.SetChainTime(now)
+				cond.apply(c)
 				return c.JSON(&cachedPoll)
 			}
 		}
+		h.metrics.CacheHit("poll", false)
 	}
 
 	// If not in cache, query database
-	var err error
 	poll, err = h.db.GetPollByAddress(ctx, address)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to retrieve poll",
-		})
+		return apierror.InternalError(c, err, "failed to retrieve poll")
 	}
 
 	if poll == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "poll not found",
-		})
+		fromChain, err := h.pollFromChain(ctx, address)
+		if err != nil {
+			log.Printf("Warning: chain read of poll %s failed: %v\n", address.Hex(), err)
+		}
+		if fromChain == nil {
+			return apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
+		}
+
+		// Not cached: the indexed poll replaces it as soon as it is stored
+		fromChain.SetChainTime(now)
+		return c.JSON(fromChain)
 	}
 
 	// Cache the poll result for 1 minute
@@ -73,13 +229,16 @@ func (h *PollHandler) GetPoll(c *fiber.Ctx) error {
 		}
 	}
 
+	poll.SetChainTime(now)
+	cond.apply(c)
 	return c.JSON(poll)
 }
 
-// ListPolls retrieves all polls with optional filtering
-// GET /api/polls?state=active&limit=10&offset=0
+// ListPolls retrieves all polls with optional filtering. state filters on
+// the on-chain state, status on the derived status that marks active polls
+// past their closing time as overdue, and tag on annotation tags.
+// GET /api/polls?state=active&status=overdue&tag=late-oracle&limit=10&offset=0
 func (h *PollHandler) ListPolls(c *fiber.Ctx) error {
-	state := c.Query("state", "")
 	limit := c.QueryInt("limit", 20)
 	offset := c.QueryInt("offset", 0)
 
@@ -87,90 +246,146 @@ func (h *PollHandler) ListPolls(c *fiber.Ctx) error {
 		limit = 100
 	}
 
-	ctx := context.Background()
-	polls, err := h.db.ListPolls(ctx, state, limit, offset)
+	ctx := c.UserContext()
+
+	now, _, err := h.chainTime(ctx)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to list polls",
-		})
+		return apierror.InternalError(c, err, "failed to get chain time")
+	}
+
+	filter := database.PollFilter{
+		State:     c.Query("state", ""),
+		Status:    c.Query("status", ""),
+		Tag:       strings.ToLower(c.Query("tag", "")),
+		ChainTime: now,
+	}
+	polls, err := h.db.ListPolls(ctx, filter, limit, offset)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list polls")
 	}
 
-	return c.JSON(fiber.Map{
-		"polls":  polls,
-		"limit":  limit,
-		"offset": offset,
-		"count":  len(polls),
+	for _, poll := range polls {
+		poll.SetChainTime(now)
+	}
+
+	return c.JSON(PollListResponse{
+		Polls:  polls,
+		Limit:  limit,
+		Offset: offset,
+		Count:  len(polls),
 	})
 }
 
-// GetPollVotes retrieves all votes for a poll
-// GET /api/polls/:address/votes?revealed_only=false
+// GetPollVotes retrieves all votes for a poll, or the votes as of a past
+// block with at_block
+// GET /api/polls/:address/votes?revealed_only=false&at_block=
 func (h *PollHandler) GetPollVotes(c *fiber.Ctx) error {
-	address := c.Params("address")
-	if address == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "poll address is required",
-		})
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
 	}
 
 	revealedOnly := c.QueryBool("revealed_only", false)
 
-	ctx := context.Background()
+	ctx := c.UserContext()
+
+	if block, ok := atBlock(c); ok {
+		view, err := h.pollAt(ctx, address, block, c.QueryBool("verify", false))
+		if err != nil {
+			return historyFailed(c, err)
+		}
+
+		votes := view.Votes
+		if revealedOnly {
+			votes = make([]*database.Vote, 0, view.Revealed)
+			for _, vote := range view.Votes {
+				if vote.Revealed {
+					votes = append(votes, vote)
+				}
+			}
+		}
+
+		// Redaction follows the poll's current state: choices revealed
+		// before a since-completed tally are no longer withheld
+		votes = h.policy.Votes(votes, view.CurrentState, privacy.ViewerOf(c))
+
+		return c.JSON(VoteListResponse{
+			Votes:      votes,
+			Count:      len(votes),
+			AtBlock:    view.Block,
+			ChainCheck: view.Check,
+		})
+	}
+
+	// Admins may see choices others may not, so the tag depends on the viewer
+	viewer := privacy.ViewerOf(c)
+	c.Vary(fiber.HeaderAuthorization, "X-API-Key")
+
+	version, err := h.db.GetPollVersion(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+	cond := newConditional(version, "votes", fmt.Sprint(viewer))
+	if cond.fresh(c) {
+		return cond.notModified(c)
+	}
+
 	votes, err := h.db.ListVotesByPoll(ctx, address, revealedOnly)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to retrieve votes",
-		})
+		return apierror.InternalError(c, err, "failed to retrieve votes")
 	}
 
-	// If not revealing, hide sensitive data
-	if !revealedOnly {
-		for _, vote := range votes {
-			if !vote.Revealed {
-				vote.Choice = nil
-				vote.Nonce = nil
-			}
-		}
+	state := ""
+	if version != nil {
+		state = version.State
 	}
 
-	return c.JSON(fiber.Map{
-		"votes": votes,
-		"count": len(votes),
+	votes = h.policy.Votes(votes, state, viewer)
+
+	cond.apply(c)
+	return c.JSON(VoteListResponse{
+		Votes: votes,
+		Count: len(votes),
 	})
 }
 
-// GetPollResults retrieves the tallied results for a poll
-// GET /api/polls/:address/results
+// GetPollResults retrieves the tallied results for a poll. Before tally,
+// provisional=true returns the running count of revealed votes instead.
+// GET /api/polls/:address/results?provisional=false
 func (h *PollHandler) GetPollResults(c *fiber.Ctx) error {
-	address := c.Params("address")
-	if address == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "poll address is required",
-		})
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
+
+	version, err := h.db.GetPollVersion(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+	cond := newConditional(version, "results")
+	if cond != nil && version.State == database.StatusTallied {
+		cond.cache = cacheFinal
+	}
+	if cond.fresh(c) {
+		return cond.notModified(c)
+	}
 
 	// Get the poll to check state
 	poll, err := h.db.GetPollByAddress(ctx, address)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to retrieve poll",
-		})
+		return apierror.InternalError(c, err, "failed to retrieve poll")
 	}
 
 	if poll == nil {
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "poll not found",
-		})
+		return apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
 	}
 
 	// Check if results exist
 	result, err := h.db.GetResultByPoll(ctx, address)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to retrieve results",
-		})
+		return apierror.InternalError(c, err, "failed to retrieve results")
 	}
 
 	if result == nil {
@@ -178,57 +393,230 @@ func (h *PollHandler) GetPollResults(c *fiber.Ctx) error {
 		if poll.State != "tallied" {
 			voteCount, err := h.db.GetVoteCount(ctx, address, false)
 			if err != nil {
-				return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-					"error": "failed to get vote count",
+				return apierror.InternalError(c, err, "failed to get vote count")
+			}
+
+			if c.QueryBool("provisional", false) {
+				tally, err := h.db.GetProvisionalTally(ctx, address)
+				if err != nil {
+					return apierror.InternalError(c, err, "failed to get provisional tally")
+				}
+
+				cond.apply(c)
+				return c.JSON(ProvisionalResultsResponse{
+					Status:        "provisional",
+					Provisional:   true,
+					PollState:     poll.State,
+					VoteCounts:    tally.VoteCounts(len(poll.Options)),
+					RevealedVotes: tally.RevealedVotes,
+					TotalVotes:    voteCount,
+					LastBlock:     tally.LastBlock,
+					UpdatedAt:     tally.UpdatedAt,
+					Message:       "provisional count of revealed votes; final results are set by tally",
 				})
 			}
 
-			return c.JSON(fiber.Map{
-				"status":      "pending",
-				"poll_state":  poll.State,
-				"total_votes": voteCount,
-				"message":     "poll results not yet tallied",
+			cond.apply(c)
+			return c.JSON(PendingResultsResponse{
+				Status:     "pending",
+				PollState:  poll.State,
+				TotalVotes: voteCount,
+				Message:    "poll results not yet tallied",
 			})
 		}
 
-		return c.Status(fiber.StatusNotFound).JSON(fiber.Map{
-			"error": "results not found",
-		})
+		return apierror.NotFoundError(c, apierror.ResultsNotFound, "results not found")
 	}
 
+	cond.apply(c)
 	return c.JSON(result)
 }
 
-// GetVoteCount retrieves vote statistics for a poll
-// GET /api/polls/:address/stats
+// GetVoteCount retrieves vote statistics for a poll, or the statistics as
+// of a past block with at_block
+// GET /api/polls/:address/stats?at_block=
 func (h *PollHandler) GetVoteCount(c *fiber.Ctx) error {
-	address := c.Params("address")
-	if address == "" {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
-			"error": "poll address is required",
-		})
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
 	}
 
-	ctx := context.Background()
+	ctx := c.UserContext()
+
+	var totalVotes, revealedVotes int
+	var view *historicalPoll
+	var cond *conditional
 
-	totalVotes, err := h.db.GetVoteCount(ctx, address, false)
+	if block, ok := atBlock(c); ok {
+		view, err = h.pollAt(ctx, address, block, c.QueryBool("verify", false))
+		if err != nil {
+			return historyFailed(c, err)
+		}
+		totalVotes, revealedVotes = view.Committed, view.Revealed
+	} else {
+		version, err := h.db.GetPollVersion(ctx, address)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to retrieve poll")
+		}
+		cond = newConditional(version, "stats")
+		if cond.fresh(c) {
+			return cond.notModified(c)
+		}
+
+		totalVotes, err = h.db.GetVoteCount(ctx, address, false)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to get vote count")
+		}
+
+		revealedVotes, err = h.db.GetVoteCount(ctx, address, true)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to get revealed vote count")
+		}
+	}
+
+	eligible, err := h.db.GetEligibleCountByPoll(ctx, address)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to get vote count",
-		})
+		return apierror.InternalError(c, err, "failed to get eligible voter count")
+	}
+
+	stats := VoteStatsResponse{
+		PollAddress:    address,
+		TotalVotes:     totalVotes,
+		RevealedVotes:  revealedVotes,
+		PendingReveals: totalVotes - revealedVotes,
+		EligibleVoters: eligible,
+	}
+	if eligible != nil && *eligible > 0 {
+		turnout := math.Round(float64(totalVotes)/float64(*eligible)*10000) / 10000
+		stats.Turnout = &turnout
+	}
+	if view != nil {
+		stats.AtBlock = view.Block
+		stats.ChainCheck = view.Check
 	}
 
-	revealedVotes, err := h.db.GetVoteCount(ctx, address, true)
+	cond.apply(c)
+	return c.JSON(stats)
+}
+
+// GetPollAnalytics summarizes a poll's outcome: option percentages, winners,
+// reveal rate, margin, and whether unrevealed votes could still change it
+// GET /api/polls/:address/analytics
+func (h *PollHandler) GetPollAnalytics(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"error": "failed to get revealed vote count",
-		})
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	ctx := c.UserContext()
+
+	poll, err := h.db.GetPollByAddress(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+
+	if poll == nil {
+		return apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
+	}
+
+	// Votes are only revealed after the poll closes
+	if poll.State == "active" {
+		return apierror.Respond(c, fiber.StatusConflict, apierror.PollActive, "analytics are available once the poll is closed")
+	}
+
+	voteCounts, err := h.db.GetVoteCountsByPolls(ctx, []database.Address{address})
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get vote count")
 	}
+	committed := voteCounts[address].Total
 
-	return c.JSON(fiber.Map{
-		"poll_address":   address,
-		"total_votes":    totalVotes,
-		"revealed_votes": revealedVotes,
-		"pending_reveals": totalVotes - revealedVotes,
+	result, err := h.db.GetResultByPoll(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve results")
+	}
+
+	// Prefer the on-chain tally; before it exists, count indexed reveals
+	source := "tally"
+	counts := make([]int, len(poll.Options))
+	if result != nil {
+		copy(counts, result.VoteCounts)
+	} else {
+		source = "reveals"
+		choices, err := h.db.CountRevealedChoices(ctx, address)
+		if err != nil {
+			return apierror.InternalError(c, err, "failed to count revealed votes")
+		}
+		for choice, n := range choices {
+			if choice >= 0 && choice < len(counts) {
+				counts[choice] = n
+			}
+		}
+	}
+
+	return c.JSON(PollAnalyticsResponse{
+		PollAddress: address,
+		PollState:   poll.State,
+		Source:      source,
+		Summary:     analytics.Summarize(poll.Options, counts, committed, result != nil),
+	})
+}
+
+// GetPollTimeseries returns commit and reveal counts per time or block bucket
+// GET /api/polls/:address/timeseries?bucket=1h
+func (h *PollHandler) GetPollTimeseries(c *fiber.Ctx) error {
+	address, err := parsePollAddress(c)
+	if err != nil {
+		return apierror.InvalidParam(c, apierror.InvalidAddress, "address", err.Error())
+	}
+
+	bucket := c.Query("bucket", database.BucketHour)
+
+	ctx := c.UserContext()
+
+	poll, err := h.db.GetPollByAddress(ctx, address)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve poll")
+	}
+
+	if poll == nil {
+		return apierror.NotFoundError(c, apierror.PollNotFound, "poll not found")
+	}
+
+	buckets, err := h.db.GetVoteTimeseries(ctx, address, bucket)
+	if errors.Is(err, database.ErrTooManyBuckets) {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "bucket", "too many buckets; use a coarser bucket size")
+	}
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get timeseries")
+	}
+
+	closeBlock, err := h.db.GetFirstEventBlock(ctx, address, "PollClosed")
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to get close block")
+	}
+
+	return c.JSON(TimeseriesResponse{
+		PollAddress: address,
+		Bucket:      bucket,
+		Buckets:     buckets,
+		Markers: TimeseriesMarkers{
+			ClosesAt:   poll.ClosesAt,
+			CloseBlock: closeBlock,
+		},
 	})
 }
+
+// parsePollAddress validates the :address route parameter and returns it in canonical form
+func parsePollAddress(c *fiber.Ctx) (database.Address, error) {
+	raw := c.Params("address")
+	if raw == "" {
+		return database.Address{}, errors.New("poll address is required")
+	}
+
+	address, err := database.ParseAddress(raw)
+	if err != nil {
+		return database.Address{}, errors.New("invalid poll address")
+	}
+
+	return address, nil
+}
@@ -0,0 +1,32 @@
+package handlers
+
+import (
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// StatsHandler serves per-chain platform statistics
+type StatsHandler struct {
+	db *database.DB
+}
+
+// NewStatsHandler creates a new platform statistics handler
+func NewStatsHandler(db *database.DB) *StatsHandler {
+	return &StatsHandler{
+		db: db,
+	}
+}
+
+// GetPlatformStats returns poll and vote totals across the chain with the
+// most active creators and voters. The figures come from materialized
+// views the indexer refreshes; refreshed_at says how current they are.
+// GET /api/stats?top=10
+func (h *StatsHandler) GetPlatformStats(c *fiber.Ctx) error {
+	stats, err := h.db.GetPlatformStats(c.UserContext(), c.QueryInt("top", 10))
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve platform statistics")
+	}
+
+	return c.JSON(stats)
+}
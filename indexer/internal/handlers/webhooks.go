@@ -0,0 +1,195 @@
+package handlers
+
+import (
+	"net/url"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/webhook"
+	"github.com/gofiber/fiber/v2"
+)
+
+// WebhookHandler handles webhook subscription requests
+type WebhookHandler struct {
+	db *database.DB
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(db *database.DB) *WebhookHandler {
+	return &WebhookHandler{
+		db: db,
+	}
+}
+
+// CreateWebhookRequest is the body accepted by CreateWebhook. Set at most
+// one of PollAddress and Creator; with neither the webhook is global.
+// Empty EventTypes subscribes to every event type.
+type CreateWebhookRequest struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	PollAddress string   `json:"poll_address,omitempty"`
+	Creator     string   `json:"creator,omitempty"`
+}
+
+// CreateWebhookResponse is returned by CreateWebhook.
+// The signing secret is only ever returned here.
+type CreateWebhookResponse struct {
+	*database.Webhook
+	Secret string `json:"secret"`
+}
+
+// WebhookListResponse is returned by ListWebhooks
+type WebhookListResponse struct {
+	Webhooks []*database.Webhook `json:"webhooks"`
+	Count    int                 `json:"count"`
+}
+
+// WebhookDeliveryListResponse is returned by ListWebhookDeliveries
+type WebhookDeliveryListResponse struct {
+	Deliveries []*database.WebhookDelivery `json:"deliveries"`
+	Count      int                         `json:"count"`
+}
+
+// CreateWebhook registers a webhook subscription
+// POST /api/admin/webhooks
+func (h *WebhookHandler) CreateWebhook(c *fiber.Ctx) error {
+	var req CreateWebhookRequest
+	if err := c.BodyParser(&req); err != nil {
+		return apierror.BadRequest(c, "invalid request body")
+	}
+
+	target, err := url.Parse(req.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return apierror.BadRequest(c, "url must be an absolute http or https URL")
+	}
+	for _, eventType := range req.EventTypes {
+		if !webhook.IsEventType(eventType) {
+			return apierror.BadRequest(c, "unknown event type: "+eventType)
+		}
+	}
+	if req.PollAddress != "" && req.Creator != "" {
+		return apierror.BadRequest(c, "set at most one of poll_address and creator")
+	}
+
+	hook := &database.Webhook{
+		URL:        target.String(),
+		EventTypes: req.EventTypes,
+	}
+	if hook.EventTypes == nil {
+		hook.EventTypes = []string{}
+	}
+	if req.PollAddress != "" {
+		address, err := database.ParseAddress(req.PollAddress)
+		if err != nil {
+			return apierror.InvalidParam(c, apierror.InvalidAddress, "poll_address", err.Error())
+		}
+		hook.PollAddress = &address
+	}
+	if req.Creator != "" {
+		address, err := database.ParseAddress(req.Creator)
+		if err != nil {
+			return apierror.InvalidParam(c, apierror.InvalidAddress, "creator", err.Error())
+		}
+		hook.Creator = &address
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to generate webhook secret")
+	}
+	hook.Secret = secret
+
+	ctx := c.UserContext()
+	if err := h.db.CreateWebhook(ctx, hook); err != nil {
+		return apierror.InternalError(c, err, "failed to create webhook")
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(CreateWebhookResponse{
+		Webhook: hook,
+		Secret:  secret,
+	})
+}
+
+// ListWebhooks lists all webhook subscriptions without their secrets
+// GET /api/admin/webhooks
+func (h *WebhookHandler) ListWebhooks(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	hooks, err := h.db.ListWebhooks(ctx)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list webhooks")
+	}
+
+	return c.JSON(WebhookListResponse{
+		Webhooks: hooks,
+		Count:    len(hooks),
+	})
+}
+
+// DeleteWebhook removes a webhook subscription and its delivery log
+// DELETE /api/admin/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "id", "invalid webhook id")
+	}
+
+	ctx := c.UserContext()
+	deleted, err := h.db.DeleteWebhook(ctx, id)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to delete webhook")
+	}
+	if !deleted {
+		return apierror.NotFoundError(c, apierror.WebhookNotFound, "webhook not found")
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// ListWebhookDeliveries returns the most recent deliveries of a webhook
+// GET /api/admin/webhooks/:id/deliveries
+func (h *WebhookHandler) ListWebhookDeliveries(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "id", "invalid webhook id")
+	}
+
+	ctx := c.UserContext()
+	hook, err := h.db.GetWebhook(ctx, id)
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to retrieve webhook")
+	}
+	if hook == nil {
+		return apierror.NotFoundError(c, apierror.WebhookNotFound, "webhook not found")
+	}
+
+	deliveries, err := h.db.ListWebhookDeliveries(ctx, id, c.QueryInt("limit", 50))
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to list webhook deliveries")
+	}
+
+	return c.JSON(WebhookDeliveryListResponse{
+		Deliveries: deliveries,
+		Count:      len(deliveries),
+	})
+}
+
+// ReplayWebhookDelivery queues a delivery to be sent again with its
+// original payload. The replay is a new entry in the log.
+// POST /api/admin/webhooks/deliveries/:id/replay
+func (h *WebhookHandler) ReplayWebhookDelivery(c *fiber.Ctx) error {
+	id, err := c.ParamsInt("id")
+	if err != nil || id <= 0 {
+		return apierror.InvalidParam(c, apierror.InvalidParameter, "id", "invalid delivery id")
+	}
+
+	ctx := c.UserContext()
+	delivery, err := h.db.ReplayWebhookDelivery(ctx, int64(id))
+	if err != nil {
+		return apierror.InternalError(c, err, "failed to replay webhook delivery")
+	}
+	if delivery == nil {
+		return apierror.NotFoundError(c, apierror.DeliveryNotFound, "delivery not found")
+	}
+
+	return c.Status(fiber.StatusAccepted).JSON(delivery)
+}
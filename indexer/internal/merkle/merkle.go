@@ -0,0 +1,133 @@
+// Package merkle builds voter eligibility trees that verify against
+// Poll._verifyMerkleProof. Leaves are keccak256(abi.encodePacked(voter))
+// and each parent hashes its two children in ascending order, so a proof
+// is just the list of sibling hashes from leaf to root.
+package merkle
+
+import (
+	"bytes"
+	"errors"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ErrEmptyTree is returned when a tree is built from no addresses
+var ErrEmptyTree = errors.New("at least one address is required")
+
+// Tree is a complete eligibility tree. levels[0] holds the sorted leaves
+// and the last level holds the root.
+type Tree struct {
+	levels [][]common.Hash
+	index  map[common.Address]int
+}
+
+// Leaf returns the leaf hash the contract computes for voter
+func Leaf(voter common.Address) common.Hash {
+	return crypto.Keccak256Hash(voter.Bytes())
+}
+
+// hashPair hashes two nodes smaller-first, matching the contract
+func hashPair(a, b common.Hash) common.Hash {
+	if bytes.Compare(a[:], b[:]) > 0 {
+		a, b = b, a
+	}
+	return crypto.Keccak256Hash(a[:], b[:])
+}
+
+// Build constructs the tree for a list of eligible addresses. Duplicates
+// are ignored and leaves are sorted by hash, so the same set of addresses
+// always produces the same root. A node without a sibling is paired with
+// itself.
+func Build(voters []common.Address) (*Tree, error) {
+	if len(voters) == 0 {
+		return nil, ErrEmptyTree
+	}
+
+	byLeaf := make(map[common.Hash]common.Address, len(voters))
+	for _, voter := range voters {
+		byLeaf[Leaf(voter)] = voter
+	}
+
+	leaves := make([]common.Hash, 0, len(byLeaf))
+	for leaf := range byLeaf {
+		leaves = append(leaves, leaf)
+	}
+	sort.Slice(leaves, func(i, j int) bool {
+		return bytes.Compare(leaves[i][:], leaves[j][:]) < 0
+	})
+
+	t := &Tree{
+		levels: [][]common.Hash{leaves},
+		index:  make(map[common.Address]int, len(leaves)),
+	}
+	for i, leaf := range leaves {
+		t.index[byLeaf[leaf]] = i
+	}
+
+	for level := leaves; len(level) > 1; {
+		next := make([]common.Hash, (len(level)+1)/2)
+		for i := range next {
+			left := level[2*i]
+			right := left
+			if 2*i+1 < len(level) {
+				right = level[2*i+1]
+			}
+			next[i] = hashPair(left, right)
+		}
+		t.levels = append(t.levels, next)
+		level = next
+	}
+
+	return t, nil
+}
+
+// Root returns the value to pass as voterMerkleRoot when creating a poll
+func (t *Tree) Root() common.Hash {
+	return t.levels[len(t.levels)-1][0]
+}
+
+// Size returns the number of distinct eligible addresses
+func (t *Tree) Size() int {
+	return len(t.levels[0])
+}
+
+// Voters returns the eligible addresses in leaf order
+func (t *Tree) Voters() []common.Address {
+	voters := make([]common.Address, len(t.index))
+	for voter, i := range t.index {
+		voters[i] = voter
+	}
+	return voters
+}
+
+// Proof returns the sibling hashes proving voter is in the tree, or false
+// if voter is not eligible. A single-voter tree has an empty proof.
+func (t *Tree) Proof(voter common.Address) ([]common.Hash, bool) {
+	i, ok := t.index[voter]
+	if !ok {
+		return nil, false
+	}
+
+	proof := make([]common.Hash, 0, len(t.levels)-1)
+	for _, level := range t.levels[:len(t.levels)-1] {
+		sibling := i ^ 1
+		if sibling >= len(level) {
+			sibling = i
+		}
+		proof = append(proof, level[sibling])
+		i /= 2
+	}
+
+	return proof, true
+}
+
+// Verify recomputes the root from voter and proof the way the contract does
+func Verify(root common.Hash, voter common.Address, proof []common.Hash) bool {
+	computed := Leaf(voter)
+	for _, sibling := range proof {
+		computed = hashPair(computed, sibling)
+	}
+	return computed == root
+}
@@ -0,0 +1,317 @@
+package openapi
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/utils"
+)
+
+// Document is an OpenAPI 3 document built from the registered routes
+type Document struct {
+	OpenAPI    string                          `json:"openapi"`
+	Info       Info                            `json:"info"`
+	Paths      map[string]map[string]*opObject `json:"paths"`
+	Components Components                      `json:"components"`
+
+	types map[reflect.Type]string
+}
+
+// Info describes the API
+type Info struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+// Components holds reusable schemas and security schemes
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*securityScheme `json:"securitySchemes,omitempty"`
+}
+
+type securityScheme struct {
+	Type string `json:"type"`
+	In   string `json:"in"`
+	Name string `json:"name"`
+}
+
+// apiKeyScheme is the name of the API key security scheme
+const apiKeyScheme = "ApiKeyAuth"
+
+// Operation describes a single route for documentation and validation
+type Operation struct {
+	ID       string
+	Summary  string
+	Tags     []string
+	Params   []Parameter
+	Body     any    // Go value whose type describes the request body, if any
+	Response any    // Go value whose type describes the success response body
+	Status   int    // Success status code; defaults to 200
+	Scope    string // API key scope required to call the route, if any
+	Errors   []int  // Status codes that return an error body
+	// Produces lists media types besides JSON that the success response
+	// may use, selected by the caller; they are documented as binary
+	Produces []string
+	// Timeout bounds the request context passed to the handler; zero uses DefaultTimeout
+	Timeout time.Duration
+	// Conditional marks routes that send ETag and Last-Modified and answer
+	// If-None-Match and If-Modified-Since with 304 Not Modified
+	Conditional bool
+}
+
+// Parameter describes a path or query parameter
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// opObject is the rendered OpenAPI operation object
+type opObject struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Parameters  []Parameter           `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+	Scope       string                `json:"x-required-scope,omitempty"`
+}
+
+type requestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// New creates an empty document
+func New(title, version string) *Document {
+	return &Document{
+		OpenAPI: "3.0.3",
+		Info:    Info{Title: title, Version: version},
+		Paths:   make(map[string]map[string]*opObject),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+		},
+		types: make(map[reflect.Type]string),
+	}
+}
+
+// Handler serves the document as JSON
+func (d *Document) Handler(c *fiber.Ctx) error {
+	return c.JSON(d)
+}
+
+// add records an operation under the given fiber path
+func (d *Document) add(method, path string, op *Operation) {
+	obj := &opObject{
+		OperationID: op.ID,
+		Summary:     op.Summary,
+		Tags:        op.Tags,
+		Parameters:  op.Params,
+		Responses:   make(map[string]*response),
+	}
+
+	if op.Body != nil {
+		obj.RequestBody = &requestBody{
+			Required: true,
+			Content:  jsonContent(d.SchemaOf(op.Body)),
+		}
+	}
+
+	status := op.Status
+	if status == 0 {
+		status = fiber.StatusOK
+	}
+	ok := &response{Description: utils.StatusMessage(status)}
+	if op.Response != nil {
+		ok.Content = jsonContent(d.SchemaOf(op.Response))
+	}
+	for _, mime := range op.Produces {
+		if ok.Content == nil {
+			ok.Content = make(map[string]*mediaType)
+		}
+		ok.Content[mime] = &mediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	obj.Responses[strconv.Itoa(status)] = ok
+
+	if op.Conditional {
+		obj.Parameters = append(append([]Parameter{}, op.Params...), conditionalParams...)
+		obj.Responses[strconv.Itoa(fiber.StatusNotModified)] = &response{
+			Description: utils.StatusMessage(fiber.StatusNotModified),
+		}
+	}
+
+	if op.Scope != "" {
+		obj.Scope = op.Scope
+		obj.Security = []map[string][]string{{apiKeyScheme: {}}}
+		if d.Components.SecuritySchemes == nil {
+			d.Components.SecuritySchemes = map[string]*securityScheme{
+				apiKeyScheme: {Type: "apiKey", In: "header", Name: "X-API-Key"},
+			}
+		}
+	}
+
+	// Every route runs under a deadline, so any of them may time out
+	errSchema := d.SchemaOf(apierror.Envelope{})
+	for _, status := range append(op.Errors, fiber.StatusGatewayTimeout) {
+		obj.Responses[strconv.Itoa(status)] = &response{
+			Description: utils.StatusMessage(status),
+			Content:     jsonContent(errSchema),
+		}
+	}
+
+	oaPath := toOpenAPIPath(path)
+	if d.Paths[oaPath] == nil {
+		d.Paths[oaPath] = make(map[string]*opObject)
+	}
+	d.Paths[oaPath][strings.ToLower(method)] = obj
+}
+
+// conditionalParams are the request headers of a conditional GET
+var conditionalParams = []Parameter{
+	{
+		Name:        fiber.HeaderIfNoneMatch,
+		In:          "header",
+		Description: "ETag of the copy the client holds; answered with 304 while it is current",
+		Schema:      &Schema{Type: "string"},
+	},
+	{
+		Name:        fiber.HeaderIfModifiedSince,
+		In:          "header",
+		Description: "Last-Modified of the copy the client holds; ignored when If-None-Match is sent",
+		Schema:      &Schema{Type: "string"},
+	},
+}
+
+func jsonContent(schema *Schema) map[string]*mediaType {
+	return map[string]*mediaType{
+		fiber.MIMEApplicationJSON: {Schema: schema},
+	}
+}
+
+// toOpenAPIPath converts fiber route syntax (/polls/:address) to OpenAPI syntax (/polls/{address})
+func toOpenAPIPath(path string) string {
+	if len(path) > 1 {
+		path = strings.TrimSuffix(path, "/")
+	}
+
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") {
+			segments[i] = "{" + strings.TrimSuffix(seg[1:], "?") + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// QueryInt describes an optional integer query parameter
+func QueryInt(name, description string, def, min, max int) Parameter {
+	minF, maxF := float64(min), float64(max)
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "integer", Default: def, Minimum: &minF, Maximum: &maxF},
+	}
+}
+
+// QueryBlock describes an optional block number query parameter
+func QueryBlock(name, description string) Parameter {
+	min := float64(0)
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "integer", Format: "int64", Minimum: &min},
+	}
+}
+
+// QueryBool describes an optional boolean query parameter
+func QueryBool(name, description string, def bool) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "boolean", Default: def},
+	}
+}
+
+// QueryEnum describes an optional string query parameter restricted to values
+func QueryEnum(name, description string, values ...string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "string", Enum: values},
+	}
+}
+
+// QueryString describes an optional free-form string query parameter
+func QueryString(name, description string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &Schema{Type: "string"},
+	}
+}
+
+// PathAddress describes a required Ethereum address path parameter
+func PathAddress(name, description string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      addressSchema(),
+	}
+}
+
+// PathHash describes a required 32-byte hex hash path parameter
+func PathHash(name, description string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      &Schema{Type: "string", Pattern: "^0x[0-9a-fA-F]{64}$"},
+	}
+}
+
+// PathBlock describes a required block number path parameter
+func PathBlock(name, description string) Parameter {
+	min := float64(0)
+	return Parameter{
+		Name:        name,
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      &Schema{Type: "integer", Format: "int64", Minimum: &min},
+	}
+}
+
+// PathString describes a required string path parameter
+func PathString(name, description string) Parameter {
+	return Parameter{
+		Name:        name,
+		In:          "path",
+		Description: description,
+		Required:    true,
+		Schema:      &Schema{Type: "string"},
+	}
+}
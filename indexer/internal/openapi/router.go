@@ -0,0 +1,174 @@
+package openapi
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/apierror"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultTimeout bounds the request context of routes that do not set
+// Operation.Timeout. It stays below the server's write timeout so a slow
+// query is cancelled before the connection is.
+const DefaultTimeout = 5 * time.Second
+
+// Router registers fiber routes and documents them in a Document.
+// Every route gets a middleware that validates its query parameters
+// against the operation's parameter schemas and attaches the route's
+// deadline to the request context before the handler runs.
+type Router struct {
+	router fiber.Router
+	prefix string
+	doc    *Document
+
+	// Set by Scope: path parameters of the prefix and the suffix that keeps
+	// the operation IDs of scoped routes unique
+	params   []Parameter
+	idSuffix string
+}
+
+// NewRouter wraps a fiber router mounted at prefix
+func NewRouter(router fiber.Router, prefix string, doc *Document) *Router {
+	return &Router{router: router, prefix: prefix, doc: doc}
+}
+
+// Group creates a sub-router
+func (r *Router) Group(prefix string, handlers ...fiber.Handler) *Router {
+	return &Router{
+		router:   r.router.Group(prefix, handlers...),
+		prefix:   r.prefix + prefix,
+		doc:      r.doc,
+		params:   r.params,
+		idSuffix: r.idSuffix,
+	}
+}
+
+// Scope creates a sub-router whose prefix holds a path parameter, for
+// registering operations that are also registered elsewhere. Its routes
+// are documented with param and with idSuffix appended to their operation
+// IDs, and may answer 404 when param names nothing.
+func (r *Router) Scope(prefix string, param Parameter, idSuffix string, handlers ...fiber.Handler) *Router {
+	scope := r.Group(prefix, handlers...)
+	scope.params = append(append([]Parameter{}, r.params...), param)
+	scope.idSuffix = r.idSuffix + idSuffix
+	return scope
+}
+
+// Get registers a GET route
+func (r *Router) Get(path string, op *Operation, handlers ...fiber.Handler) {
+	r.add(fiber.MethodGet, path, op, handlers)
+}
+
+// Post registers a POST route
+func (r *Router) Post(path string, op *Operation, handlers ...fiber.Handler) {
+	r.add(fiber.MethodPost, path, op, handlers)
+}
+
+// Put registers a PUT route
+func (r *Router) Put(path string, op *Operation, handlers ...fiber.Handler) {
+	r.add(fiber.MethodPut, path, op, handlers)
+}
+
+// Delete registers a DELETE route
+func (r *Router) Delete(path string, op *Operation, handlers ...fiber.Handler) {
+	r.add(fiber.MethodDelete, path, op, handlers)
+}
+
+func (r *Router) add(method, path string, op *Operation, handlers []fiber.Handler) {
+	r.doc.add(method, r.prefix+path, r.scoped(op))
+	chain := append([]fiber.Handler{validateQuery(op), withDeadline(op)}, handlers...)
+	r.router.Add(method, path, chain...)
+}
+
+// scoped returns the operation as documented on this router
+func (r *Router) scoped(op *Operation) *Operation {
+	if len(r.params) == 0 {
+		return op
+	}
+
+	scoped := *op
+	scoped.ID = op.ID + r.idSuffix
+	scoped.Params = append(append([]Parameter{}, r.params...), op.Params...)
+	if !slices.Contains(op.Errors, fiber.StatusNotFound) {
+		scoped.Errors = append(append([]int{}, op.Errors...), fiber.StatusNotFound)
+	}
+	return &scoped
+}
+
+// withDeadline attaches the operation's timeout to the handler context;
+// handlers pass c.UserContext() to the database. fasthttp does not report
+// a client that disconnects mid-request, so the deadline, not the client,
+// is what ends a slow query.
+func withDeadline(op *Operation) fiber.Handler {
+	timeout := op.Timeout
+	if timeout == 0 {
+		timeout = DefaultTimeout
+	}
+
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		return c.Next()
+	}
+}
+
+// validateQuery rejects requests whose query parameters do not match the operation
+func validateQuery(op *Operation) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		for _, p := range op.Params {
+			if p.In != "query" {
+				continue
+			}
+
+			raw := c.Query(p.Name)
+			if raw == "" {
+				if p.Required {
+					return apierror.InvalidParam(c, apierror.InvalidParameter, p.Name, fmt.Sprintf("query parameter %s is required", p.Name))
+				}
+				continue
+			}
+
+			if err := p.Schema.validate(raw); err != nil {
+				return apierror.InvalidParam(c, apierror.InvalidParameter, p.Name, fmt.Sprintf("invalid query parameter %s: %v", p.Name, err))
+			}
+		}
+		return c.Next()
+	}
+}
+
+// validate checks a raw query string value against a scalar schema
+func (s *Schema) validate(raw string) error {
+	switch s.Type {
+	case "integer":
+		n, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			return fmt.Errorf("must be an integer")
+		}
+		if s.Minimum != nil && float64(n) < *s.Minimum {
+			return fmt.Errorf("must be >= %v", *s.Minimum)
+		}
+		if s.Maximum != nil && float64(n) > *s.Maximum {
+			return fmt.Errorf("must be <= %v", *s.Maximum)
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(raw); err != nil {
+			return fmt.Errorf("must be a boolean")
+		}
+	case "string":
+		if len(s.Enum) > 0 {
+			for _, v := range s.Enum {
+				if raw == v {
+					return nil
+				}
+			}
+			return fmt.Errorf("must be one of %v", s.Enum)
+		}
+	}
+	return nil
+}
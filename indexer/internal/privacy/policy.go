@@ -0,0 +1,113 @@
+// Package privacy is the single place that decides which vote fields an
+// API response may carry. Every endpoint that returns votes, or the raw
+// events they were indexed from, passes them through a Policy first.
+//
+// The rules are:
+//   - the commitment salt (nonce) is never returned
+//   - a choice is only returned once the vote is revealed
+//   - with LinkageAfterTally set, public callers see no per-voter choices,
+//     including the raw VoteRevealed log data, until the poll is tallied;
+//     aggregate counts are unaffected
+package privacy
+
+import (
+	"context"
+	"os"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/auth"
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Viewer is the audience a response is prepared for
+type Viewer int
+
+const (
+	// Public is any anonymous or read-scoped caller
+	Public Viewer = iota
+	// Operator is a caller with the admin scope
+	Operator
+)
+
+// talliedState is the poll state after which choices are no longer withheld
+const talliedState = "tallied"
+
+// Policy decides which vote fields a viewer may see
+type Policy struct {
+	// LinkageAfterTally withholds revealed choices from public viewers until
+	// the poll is tallied, so voters are not linked to choices mid-reveal
+	LinkageAfterTally bool
+}
+
+// PolicyFromEnv reads the policy from VOTE_LINKAGE_AFTER_TALLY
+func PolicyFromEnv() Policy {
+	return Policy{
+		LinkageAfterTally: os.Getenv("VOTE_LINKAGE_AFTER_TALLY") == "true",
+	}
+}
+
+// NeedsPollState reports whether redaction depends on the poll state, so
+// callers can skip looking it up
+func (p Policy) NeedsPollState() bool {
+	return p.LinkageAfterTally
+}
+
+// withholdChoices reports whether revealed choices are hidden from viewer
+func (p Policy) withholdChoices(pollState string, viewer Viewer) bool {
+	return p.LinkageAfterTally && viewer != Operator && pollState != talliedState
+}
+
+// Vote returns a copy of vote with the fields viewer may not see cleared
+func (p Policy) Vote(vote *database.Vote, pollState string, viewer Viewer) *database.Vote {
+	redacted := *vote
+	redacted.Nonce = nil
+	if !vote.Revealed || p.withholdChoices(pollState, viewer) {
+		redacted.Choice = nil
+	}
+	return &redacted
+}
+
+// Votes redacts a list of votes from a single poll
+func (p Policy) Votes(votes []*database.Vote, pollState string, viewer Viewer) []*database.Vote {
+	redacted := make([]*database.Vote, len(votes))
+	for i, vote := range votes {
+		redacted[i] = p.Vote(vote, pollState, viewer)
+	}
+	return redacted
+}
+
+// Event returns a copy of event with the log data cleared if it would link
+// a voter to a choice viewer may not see yet
+func (p Policy) Event(event *database.Event, pollState string, viewer Viewer) *database.Event {
+	redacted := *event
+	if event.EventName == "VoteRevealed" && p.withholdChoices(pollState, viewer) {
+		redacted.EventData = ""
+	}
+	return &redacted
+}
+
+// ViewerOf returns the viewer for the request's API key
+func ViewerOf(c *fiber.Ctx) Viewer {
+	return ViewerForKey(auth.KeyFromContext(c))
+}
+
+// ViewerForKey returns the viewer for an API key, which may be nil
+func ViewerForKey(key *database.APIKey) Viewer {
+	if auth.HasScope(key, auth.ScopeAdmin) {
+		return Operator
+	}
+	return Public
+}
+
+type viewerKey struct{}
+
+// WithViewer attaches viewer to ctx for resolvers that have no fiber.Ctx
+func WithViewer(ctx context.Context, viewer Viewer) context.Context {
+	return context.WithValue(ctx, viewerKey{}, viewer)
+}
+
+// ViewerFrom returns the viewer attached to ctx, defaulting to Public
+func ViewerFrom(ctx context.Context) Viewer {
+	viewer, _ := ctx.Value(viewerKey{}).(Viewer)
+	return viewer
+}
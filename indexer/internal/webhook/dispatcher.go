@@ -0,0 +1,195 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
+)
+
+const (
+	// requestTimeout bounds a single delivery attempt
+	requestTimeout = 10 * time.Second
+	// claimLease keeps a claimed delivery from being picked up again while
+	// it is in flight
+	claimLease = 2 * requestTimeout
+	// batchSize is the number of deliveries claimed per poll
+	batchSize = 20
+)
+
+// Config controls delivery retries
+type Config struct {
+	MaxAttempts  int           // Attempts before a delivery is marked failed
+	BaseDelay    time.Duration // Delay before the first retry; doubled per attempt
+	MaxDelay     time.Duration // Upper bound on the retry delay
+	PollInterval time.Duration // How often due deliveries are claimed
+}
+
+// ConfigFromEnv reads WEBHOOK_MAX_ATTEMPTS (default 8),
+// WEBHOOK_RETRY_BASE (default 30s) and WEBHOOK_RETRY_MAX (default 1h)
+func ConfigFromEnv() Config {
+	cfg := Config{
+		MaxAttempts:  8,
+		BaseDelay:    30 * time.Second,
+		MaxDelay:     time.Hour,
+		PollInterval: 5 * time.Second,
+	}
+
+	if v := os.Getenv("WEBHOOK_MAX_ATTEMPTS"); v != "" {
+		if n, err := strconv.Atoi(v); err == nil && n > 0 {
+			cfg.MaxAttempts = n
+		}
+	}
+	if v := os.Getenv("WEBHOOK_RETRY_BASE"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.BaseDelay = d
+		}
+	}
+	if v := os.Getenv("WEBHOOK_RETRY_MAX"); v != "" {
+		if d, err := time.ParseDuration(v); err == nil && d > 0 {
+			cfg.MaxDelay = d
+		}
+	}
+
+	return cfg
+}
+
+// Backoff returns the delay before the retry that follows attempt
+// (1-based): BaseDelay doubled per earlier attempt, capped at MaxDelay
+func (c Config) Backoff(attempt int) time.Duration {
+	delay := c.BaseDelay
+	for i := 1; i < attempt && delay < c.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > c.MaxDelay {
+		delay = c.MaxDelay
+	}
+	return delay
+}
+
+// Dispatcher sends queued deliveries and records each attempt
+type Dispatcher struct {
+	db      *database.DB
+	client  *http.Client
+	config  Config
+	metrics *instrumentation.Indexer
+}
+
+// NewDispatcher creates a new dispatcher
+func NewDispatcher(db *database.DB, config Config, metrics *instrumentation.Indexer) *Dispatcher {
+	return &Dispatcher{
+		db:      db,
+		client:  &http.Client{Timeout: requestTimeout},
+		config:  config,
+		metrics: metrics,
+	}
+}
+
+// Run sends due deliveries until ctx is cancelled
+func (d *Dispatcher) Run(ctx context.Context) error {
+	log.Println("Starting webhook dispatcher...")
+
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("Stopping webhook dispatcher...")
+			return ctx.Err()
+		case <-ticker.C:
+			if err := d.dispatch(ctx); err != nil && ctx.Err() == nil {
+				log.Printf("Warning: %v\n", err)
+			}
+		}
+	}
+}
+
+// dispatch claims and sends batches until nothing is due
+func (d *Dispatcher) dispatch(ctx context.Context) error {
+	for {
+		deliveries, err := d.db.ClaimWebhookDeliveries(ctx, batchSize, claimLease)
+		if err != nil {
+			return err
+		}
+
+		for _, delivery := range deliveries {
+			if err := d.attempt(ctx, delivery); err != nil {
+				return err
+			}
+		}
+
+		if len(deliveries) < batchSize {
+			return nil
+		}
+	}
+}
+
+// attempt sends one delivery and records the outcome
+func (d *Dispatcher) attempt(ctx context.Context, delivery *database.WebhookDelivery) error {
+	statusCode, sendErr := d.send(ctx, delivery)
+
+	var lastError *string
+	if sendErr != nil {
+		msg := sendErr.Error()
+		lastError = &msg
+	}
+
+	attempt := delivery.Attempts + 1
+	status := database.DeliveryDelivered
+	next := time.Now()
+	outcome := "delivered"
+
+	switch {
+	case sendErr == nil:
+	case attempt >= d.config.MaxAttempts:
+		status = database.DeliveryFailed
+		outcome = "failed"
+		log.Printf("Warning: webhook delivery %d to %s failed after %d attempts: %v\n",
+			delivery.ID, delivery.URL, attempt, sendErr)
+	default:
+		status = database.DeliveryPending
+		next = next.Add(d.config.Backoff(attempt))
+		outcome = "retry"
+	}
+
+	d.metrics.WebhookDeliveries.WithLabelValues(outcome).Inc()
+	return d.db.RecordWebhookAttempt(ctx, delivery.ID, status, statusCode, lastError, next)
+}
+
+// send POSTs the signed payload. Any non-2xx response is an error.
+func (d *Dispatcher) send(ctx context.Context, delivery *database.WebhookDelivery) (*int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.URL, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	timestamp := time.Now().Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "blockchain-qa-indexer")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(delivery.Secret, timestamp, delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return &resp.StatusCode, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return &resp.StatusCode, nil
+}
@@ -0,0 +1,93 @@
+// Package webhook notifies subscribers of poll lifecycle transitions.
+//
+// The indexer enqueues a delivery per matching subscription in the
+// webhook_deliveries table when a poll changes state, and a Dispatcher
+// sends them. Every request is a JSON Payload signed with the
+// subscription's secret:
+//
+//	X-Webhook-Signature: sha256=<hex HMAC-SHA256(secret, timestamp + "." + body)>
+//
+// where timestamp is the X-Webhook-Timestamp header in Unix seconds.
+// Receivers should recompute the signature over the raw body and reject
+// stale timestamps.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Cosmos-Harry/blockchain-qa/indexer/internal/database"
+)
+
+// Event types a webhook can subscribe to
+const (
+	EventPollClosed  = "poll.closed"
+	EventPollTallied = "poll.tallied"
+)
+
+// EventTypes lists every supported event type
+var EventTypes = []string{EventPollClosed, EventPollTallied}
+
+// Request headers set on every delivery
+const (
+	HeaderSignature = "X-Webhook-Signature"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderEvent     = "X-Webhook-Event"
+	HeaderDelivery  = "X-Webhook-Delivery"
+)
+
+// secretPrefix marks webhook secrets so they are not mistaken for API keys
+const secretPrefix = "whsec_"
+
+// Payload is the JSON body sent to subscribers
+type Payload struct {
+	Type            string           `json:"type"`
+	OccurredAt      time.Time        `json:"occurred_at"`
+	BlockNumber     int64            `json:"block_number"`
+	TransactionHash string           `json:"transaction_hash"`
+	Poll            *database.Poll   `json:"poll"`
+	Result          *database.Result `json:"result,omitempty"` // Set for poll.tallied
+}
+
+// IsEventType reports whether t is a supported event type
+func IsEventType(t string) bool {
+	for _, known := range EventTypes {
+		if t == known {
+			return true
+		}
+	}
+	return false
+}
+
+// NewSecret creates a random signing secret for a subscription
+func NewSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
+
+// Sign returns the X-Webhook-Signature value for a body sent at timestamp
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	fmt.Fprintf(mac, "%d.", timestamp)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Enqueue queues payload for every subscription matching its type and poll.
+// It is safe to call again for the same transition.
+func Enqueue(ctx context.Context, db *database.DB, payload *Payload) (int, error) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return db.EnqueueWebhookDeliveries(ctx, payload.Type, payload.Poll, body)
+}
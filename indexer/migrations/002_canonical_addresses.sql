@@ -0,0 +1,22 @@
+-- Normalize stored addresses to lowercase hex.
+-- The API renders addresses in checksummed form; storage is always lowercase
+-- so lookups do not depend on the letter case supplied by the caller.
+UPDATE polls SET contract_address = LOWER(contract_address), creator = LOWER(creator);
+UPDATE votes SET poll_address = LOWER(poll_address), voter = LOWER(voter);
+UPDATE events SET contract_address = LOWER(contract_address);
+UPDATE results SET poll_address = LOWER(poll_address);
+
+-- Reject anything that is not a canonical address
+ALTER TABLE polls
+    ADD CONSTRAINT polls_contract_address_canonical CHECK (contract_address ~ '^0x[0-9a-f]{40}$'),
+    ADD CONSTRAINT polls_creator_canonical CHECK (creator ~ '^0x[0-9a-f]{40}$');
+
+ALTER TABLE votes
+    ADD CONSTRAINT votes_poll_address_canonical CHECK (poll_address ~ '^0x[0-9a-f]{40}$'),
+    ADD CONSTRAINT votes_voter_canonical CHECK (voter ~ '^0x[0-9a-f]{40}$');
+
+ALTER TABLE events
+    ADD CONSTRAINT events_contract_address_canonical CHECK (contract_address ~ '^0x[0-9a-f]{40}$');
+
+ALTER TABLE results
+    ADD CONSTRAINT results_poll_address_canonical CHECK (poll_address ~ '^0x[0-9a-f]{40}$');
@@ -0,0 +1,10 @@
+-- Create indexer_status table: one heartbeat row per listener.
+-- The indexer updates it as it processes blocks and on a timer, so the API
+-- can report indexing lag without its own RPC connection.
+CREATE TABLE IF NOT EXISTS indexer_status (
+    listener_id VARCHAR(100) PRIMARY KEY,
+    last_block BIGINT NOT NULL DEFAULT 0,
+    head_block BIGINT NOT NULL DEFAULT 0,
+    subscribed BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,25 @@
+-- Provisional tallies: running per-option counts built from VoteRevealed
+-- events while a poll is closed but not yet tallied on-chain.
+-- provisional_reveals records each counted reveal so replaying a block
+-- range never counts a voter twice.
+CREATE TABLE IF NOT EXISTS provisional_reveals (
+    poll_address VARCHAR(42) NOT NULL CHECK (poll_address ~ '^0x[0-9a-f]{40}$'),
+    voter VARCHAR(42) NOT NULL CHECK (voter ~ '^0x[0-9a-f]{40}$'),
+    choice INTEGER NOT NULL,
+    block_number BIGINT NOT NULL,
+    created_timestamp TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (poll_address, voter)
+);
+
+CREATE TABLE IF NOT EXISTS provisional_tallies (
+    poll_address VARCHAR(42) NOT NULL CHECK (poll_address ~ '^0x[0-9a-f]{40}$'),
+    choice INTEGER NOT NULL,
+    votes INTEGER NOT NULL DEFAULT 0,
+    last_block BIGINT NOT NULL,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (poll_address, choice)
+);
+
+-- Whether the provisional tally agreed with the on-chain result.
+-- NULL when no reveals were counted before the tally.
+ALTER TABLE results ADD COLUMN IF NOT EXISTS provisional_match BOOLEAN;
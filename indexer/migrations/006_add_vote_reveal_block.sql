@@ -0,0 +1,5 @@
+-- Record the block each vote was revealed in, alongside revealed_at,
+-- so reveal activity can be bucketed by block as well as by time.
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS revealed_block BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_votes_committed_at ON votes(poll_address, committed_at);
@@ -0,0 +1,5 @@
+-- Gas used by the transaction that emitted each event, read from its
+-- receipt when the event is indexed. Used by the poll audit export.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS gas_used BIGINT;
+
+CREATE INDEX IF NOT EXISTS idx_events_transaction ON events(transaction_hash);
@@ -0,0 +1,18 @@
+-- Voter eligibility trees registered through the API, keyed by the root
+-- passed to the poll as voterMerkleRoot. Each eligible voter's proof is
+-- stored precomputed so lookups never rebuild the tree.
+CREATE TABLE IF NOT EXISTS merkle_trees (
+    root VARCHAR(66) PRIMARY KEY CHECK (root ~ '^0x[0-9a-f]{64}$'),
+    eligible_count INTEGER NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS merkle_proofs (
+    root VARCHAR(66) NOT NULL REFERENCES merkle_trees(root) ON DELETE CASCADE,
+    voter VARCHAR(42) NOT NULL CHECK (voter ~ '^0x[0-9a-f]{40}$'),
+    leaf VARCHAR(66) NOT NULL,
+    proof TEXT[] NOT NULL,
+    PRIMARY KEY (root, voter)
+);
+
+CREATE INDEX IF NOT EXISTS idx_polls_voter_merkle_root ON polls(voter_merkle_root);
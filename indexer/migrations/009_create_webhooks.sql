@@ -0,0 +1,43 @@
+-- Webhook subscriptions. A webhook is global when both poll_address and
+-- creator are NULL, otherwise it only fires for the matching poll or for
+-- polls created by the matching address. An empty event_types array
+-- subscribes to every event type.
+CREATE TABLE IF NOT EXISTS webhooks (
+    id SERIAL PRIMARY KEY,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    event_types TEXT[] NOT NULL DEFAULT '{}',
+    poll_address VARCHAR(42) CHECK (poll_address ~ '^0x[0-9a-f]{40}$'),
+    creator VARCHAR(42) CHECK (creator ~ '^0x[0-9a-f]{40}$'),
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Delivery log and outbox. The indexer inserts a pending row per matching
+-- webhook when a poll changes state; the dispatcher claims due rows,
+-- records each attempt and reschedules failures with exponential backoff.
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id BIGSERIAL PRIMARY KEY,
+    webhook_id INTEGER NOT NULL REFERENCES webhooks(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    poll_address VARCHAR(42) NOT NULL CHECK (poll_address ~ '^0x[0-9a-f]{40}$'),
+    payload JSONB NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_status_code INTEGER,
+    last_error TEXT,
+    replay_of BIGINT REFERENCES webhook_deliveries(id),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    delivered_at TIMESTAMP
+);
+
+-- Each transition is enqueued once per webhook even if the block range
+-- is reprocessed; replays are extra rows and are not constrained
+CREATE UNIQUE INDEX IF NOT EXISTS idx_webhook_deliveries_once
+    ON webhook_deliveries(webhook_id, event_type, poll_address)
+    WHERE replay_of IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due
+    ON webhook_deliveries(next_attempt_at)
+    WHERE status = 'pending';
@@ -0,0 +1,55 @@
+-- Operator control of the indexer. The API writes these tables and the
+-- indexer polls them, so the two binaries never talk to each other directly.
+
+-- Single-row switch for pausing block processing
+CREATE TABLE IF NOT EXISTS indexer_control (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    paused BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO indexer_control (id) VALUES (TRUE) ON CONFLICT (id) DO NOTHING;
+
+-- Whether the listener has acknowledged a pause, reported with its heartbeat
+ALTER TABLE indexer_status ADD COLUMN IF NOT EXISTS paused BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Reindex requests, run by the indexer in submission order. A poll
+-- reindex carries the poll address and the block range it was resolved to.
+CREATE TABLE IF NOT EXISTS indexer_commands (
+    id BIGSERIAL PRIMARY KEY,
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('reindex_range', 'reindex_poll')),
+    from_block BIGINT NOT NULL,
+    to_block BIGINT NOT NULL,
+    poll_address VARCHAR(42) CHECK (poll_address ~ '^0x[0-9a-f]{40}$'),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    error TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP,
+    finished_at TIMESTAMP,
+    CHECK (from_block <= to_block)
+);
+
+CREATE INDEX IF NOT EXISTS idx_indexer_commands_pending
+    ON indexer_commands(id)
+    WHERE status = 'pending';
+
+-- Events whose handler failed. The raw event is stored either way; a
+-- row here is resolved when a reindex processes the event successfully.
+CREATE TABLE IF NOT EXISTS failed_events (
+    id BIGSERIAL PRIMARY KEY,
+    contract_address VARCHAR(42) NOT NULL CHECK (contract_address ~ '^0x[0-9a-f]{40}$'),
+    event_name VARCHAR(50) NOT NULL,
+    block_number BIGINT NOT NULL,
+    transaction_hash VARCHAR(66) NOT NULL,
+    log_index INTEGER NOT NULL,
+    error TEXT NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    first_failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_failed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP,
+    UNIQUE (transaction_hash, log_index)
+);
+
+CREATE INDEX IF NOT EXISTS idx_failed_events_unresolved
+    ON failed_events(block_number)
+    WHERE resolved_at IS NULL;
@@ -0,0 +1,9 @@
+-- Timestamp of the chain head as last seen by each listener. Deadlines such
+-- as a poll's closes_at are judged against chain time, which a test chain
+-- can move independently of the wall clock.
+ALTER TABLE indexer_status ADD COLUMN IF NOT EXISTS head_time TIMESTAMP;
+
+-- Active polls by closing time, for finding polls left open past it
+CREATE INDEX IF NOT EXISTS idx_polls_active_closes_at
+    ON polls(closes_at)
+    WHERE state = 'active';
@@ -0,0 +1,4 @@
+-- Indexes for looking up what a transaction or block touched.
+-- events(transaction_hash) already exists (007).
+CREATE INDEX IF NOT EXISTS idx_events_block_log ON events(block_number, log_index);
+CREATE INDEX IF NOT EXISTS idx_votes_transaction ON votes(transaction_hash);
@@ -0,0 +1,72 @@
+-- When each poll was closed on chain, from its PollClosed event
+ALTER TABLE polls ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP;
+
+-- Backfill from stored PollClosed events; the event data is the hex-encoded
+-- uint256 close timestamp, which fits in its low 64 bits
+UPDATE polls p
+SET closed_at = to_timestamp(('x' || right(e.event_data->>'data', 16))::bit(64)::bigint) AT TIME ZONE 'UTC'
+FROM events e
+WHERE e.contract_address = p.contract_address
+    AND e.event_name = 'PollClosed'
+    AND p.closed_at IS NULL;
+
+-- Platform-wide aggregates behind /api/stats. The indexer refreshes them
+-- concurrently after processing blocks, so each needs a unique index.
+
+-- Polls by on-chain state
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_poll_states AS
+SELECT state, COUNT(*)::int AS polls
+FROM polls
+GROUP BY state;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_poll_states ON platform_poll_states(state);
+
+-- Single row of vote totals and timing averages. The reveal rate is the
+-- mean of per-poll rates over polls with at least one commit.
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_totals AS
+WITH per_poll AS (
+    SELECT p.contract_address,
+        COUNT(v.id) AS commits,
+        COUNT(v.id) FILTER (WHERE v.revealed) AS reveals
+    FROM polls p
+    LEFT JOIN votes v ON v.poll_address = p.contract_address
+    GROUP BY p.contract_address
+)
+SELECT TRUE AS id,
+    COALESCE(SUM(commits), 0)::bigint AS total_commits,
+    COALESCE(SUM(reveals), 0)::bigint AS total_reveals,
+    AVG(reveals::float8 / commits) FILTER (WHERE commits > 0) AS avg_reveal_rate,
+    (SELECT AVG(EXTRACT(EPOCH FROM closed_at - closes_at))::float8
+        FROM polls WHERE closed_at IS NOT NULL) AS avg_close_delay_seconds
+FROM per_poll;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_totals ON platform_totals(id);
+
+-- Activity per poll creator
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_creators AS
+SELECT creator AS address, COUNT(*)::int AS polls, MAX(created_at) AS last_active_at
+FROM polls
+GROUP BY creator;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_creators ON platform_creators(address);
+CREATE INDEX IF NOT EXISTS idx_platform_creators_polls ON platform_creators(polls DESC);
+
+-- Activity per voter
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_voters AS
+SELECT voter AS address,
+    COUNT(*)::int AS commits,
+    COUNT(*) FILTER (WHERE revealed)::int AS reveals,
+    MAX(committed_at) AS last_active_at
+FROM votes
+GROUP BY voter;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_voters ON platform_voters(address);
+CREATE INDEX IF NOT EXISTS idx_platform_voters_commits ON platform_voters(commits DESC);
+
+-- When the views were last refreshed
+CREATE TABLE IF NOT EXISTS platform_stats_refresh (
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    refreshed_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO platform_stats_refresh (id) VALUES (TRUE) ON CONFLICT (id) DO NOTHING;
@@ -0,0 +1,15 @@
+-- Off-chain labels for polls: tags, notes and a scenario ID, set through
+-- the API by keys with the annotate scope. They never affect indexing.
+CREATE TABLE IF NOT EXISTS poll_annotations (
+    poll_address VARCHAR(42) PRIMARY KEY REFERENCES polls(contract_address) ON DELETE CASCADE,
+    tags TEXT[] NOT NULL DEFAULT '{}',
+    notes TEXT NOT NULL DEFAULT '',
+    scenario_id VARCHAR(100),
+    updated_by VARCHAR(100), -- Name of the API key that last changed the row
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- ListPolls filters on tag membership
+CREATE INDEX IF NOT EXISTS idx_poll_annotations_tags ON poll_annotations USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_poll_annotations_scenario ON poll_annotations(scenario_id);
@@ -0,0 +1,164 @@
+-- Index several chains from one database. Every table of indexed data and
+-- indexer state gains the chain it belongs to, and keys that were unique
+-- per contract become unique per (chain, contract): the same contracts are
+-- deployed at the same addresses on Anvil and on a testnet.
+--
+-- API keys and voter trees stay shared by all chains. A voter tree is keyed
+-- by its root, which commits to the voters and not to a chain, so one
+-- registered tree serves polls with that root on any chain.
+--
+-- Existing rows were indexed from a single chain before this migration and
+-- are assigned to Anvil (31337). A database that indexed another chain
+-- should update chain_id to that chain's ID right after applying it.
+
+ALTER TABLE polls ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE votes ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE results ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE provisional_reveals ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE provisional_tallies ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE poll_annotations ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE webhooks ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE webhook_deliveries ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE indexer_status ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE indexer_commands ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE failed_events ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+
+-- New rows always name their chain
+ALTER TABLE polls ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE votes ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE events ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE results ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE provisional_reveals ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE provisional_tallies ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE poll_annotations ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE webhooks ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE webhook_deliveries ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE indexer_status ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE indexer_commands ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE failed_events ALTER COLUMN chain_id DROP DEFAULT;
+
+-- Per-chain uniqueness. The annotation foreign key depends on the polls
+-- key, so it is replaced by one on (chain_id, contract_address).
+ALTER TABLE poll_annotations DROP CONSTRAINT IF EXISTS poll_annotations_poll_address_fkey;
+
+ALTER TABLE polls DROP CONSTRAINT IF EXISTS polls_contract_address_key;
+ALTER TABLE polls ADD CONSTRAINT polls_chain_contract_address_key UNIQUE (chain_id, contract_address);
+
+ALTER TABLE votes DROP CONSTRAINT IF EXISTS votes_poll_address_voter_key;
+ALTER TABLE votes ADD CONSTRAINT votes_chain_poll_address_voter_key UNIQUE (chain_id, poll_address, voter);
+
+ALTER TABLE events DROP CONSTRAINT IF EXISTS events_transaction_hash_log_index_key;
+ALTER TABLE events ADD CONSTRAINT events_chain_transaction_hash_log_index_key UNIQUE (chain_id, transaction_hash, log_index);
+
+ALTER TABLE results DROP CONSTRAINT IF EXISTS results_poll_address_key;
+ALTER TABLE results ADD CONSTRAINT results_chain_poll_address_key UNIQUE (chain_id, poll_address);
+
+ALTER TABLE provisional_reveals DROP CONSTRAINT IF EXISTS provisional_reveals_pkey;
+ALTER TABLE provisional_reveals ADD PRIMARY KEY (chain_id, poll_address, voter);
+
+ALTER TABLE provisional_tallies DROP CONSTRAINT IF EXISTS provisional_tallies_pkey;
+ALTER TABLE provisional_tallies ADD PRIMARY KEY (chain_id, poll_address, choice);
+
+ALTER TABLE poll_annotations DROP CONSTRAINT IF EXISTS poll_annotations_pkey;
+ALTER TABLE poll_annotations ADD PRIMARY KEY (chain_id, poll_address);
+ALTER TABLE poll_annotations
+    ADD CONSTRAINT poll_annotations_poll_fkey FOREIGN KEY (chain_id, poll_address)
+    REFERENCES polls(chain_id, contract_address) ON DELETE CASCADE;
+
+ALTER TABLE failed_events DROP CONSTRAINT IF EXISTS failed_events_transaction_hash_log_index_key;
+ALTER TABLE failed_events ADD CONSTRAINT failed_events_chain_transaction_hash_log_index_key UNIQUE (chain_id, transaction_hash, log_index);
+
+-- The factory that deployed each poll. Several factories can be indexed on
+-- one chain, and each listener resumes from its own factory's events.
+-- Backfilled from the PollCreated event logged in the poll's creation
+-- transaction; NULL for a poll indexed without it.
+ALTER TABLE polls ADD COLUMN IF NOT EXISTS factory VARCHAR(42) CHECK (factory ~ '^0x[0-9a-f]{40}$');
+
+UPDATE polls p
+SET factory = e.contract_address
+FROM events e
+WHERE e.chain_id = p.chain_id
+    AND e.transaction_hash = p.transaction_hash
+    AND e.event_name = 'PollCreated'
+    AND p.factory IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_polls_factory ON polls(chain_id, factory);
+
+-- Listeners are identified by <chain ID>:<factory address>
+UPDATE indexer_status SET listener_id = chain_id || ':' || listener_id WHERE listener_id NOT LIKE '%:%';
+
+-- The pause switch is per chain; a chain without a row is running
+ALTER TABLE indexer_control DROP CONSTRAINT IF EXISTS indexer_control_pkey;
+ALTER TABLE indexer_control DROP COLUMN IF EXISTS id;
+ALTER TABLE indexer_control ADD COLUMN IF NOT EXISTS chain_id BIGINT NOT NULL DEFAULT 31337;
+ALTER TABLE indexer_control ALTER COLUMN chain_id DROP DEFAULT;
+ALTER TABLE indexer_control ADD PRIMARY KEY (chain_id);
+
+-- A command runs on the listener of its factory, or on any listener of
+-- its chain when no factory is set
+ALTER TABLE indexer_commands ADD COLUMN IF NOT EXISTS factory VARCHAR(42) CHECK (factory ~ '^0x[0-9a-f]{40}$');
+
+DROP INDEX IF EXISTS idx_indexer_commands_pending;
+CREATE INDEX IF NOT EXISTS idx_indexer_commands_pending
+    ON indexer_commands(chain_id, id)
+    WHERE status = 'pending';
+
+-- Lookups that filter on a poll or block now also filter on its chain
+CREATE INDEX IF NOT EXISTS idx_votes_chain_poll ON votes(chain_id, poll_address);
+CREATE INDEX IF NOT EXISTS idx_events_chain_contract ON events(chain_id, contract_address);
+CREATE INDEX IF NOT EXISTS idx_events_chain_block ON events(chain_id, block_number DESC);
+CREATE INDEX IF NOT EXISTS idx_polls_chain_created_at ON polls(chain_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhooks_chain ON webhooks(chain_id);
+
+-- The platform statistics views are rebuilt per chain
+DROP MATERIALIZED VIEW IF EXISTS platform_poll_states;
+DROP MATERIALIZED VIEW IF EXISTS platform_totals;
+DROP MATERIALIZED VIEW IF EXISTS platform_creators;
+DROP MATERIALIZED VIEW IF EXISTS platform_voters;
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_poll_states AS
+SELECT chain_id, state, COUNT(*)::int AS polls
+FROM polls
+GROUP BY chain_id, state;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_poll_states ON platform_poll_states(chain_id, state);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_totals AS
+WITH per_poll AS (
+    SELECT p.chain_id, p.contract_address,
+        COUNT(v.id) AS commits,
+        COUNT(v.id) FILTER (WHERE v.revealed) AS reveals
+    FROM polls p
+    LEFT JOIN votes v ON v.chain_id = p.chain_id AND v.poll_address = p.contract_address
+    GROUP BY p.chain_id, p.contract_address
+)
+SELECT per_poll.chain_id,
+    COALESCE(SUM(commits), 0)::bigint AS total_commits,
+    COALESCE(SUM(reveals), 0)::bigint AS total_reveals,
+    AVG(reveals::float8 / commits) FILTER (WHERE commits > 0) AS avg_reveal_rate,
+    (SELECT AVG(EXTRACT(EPOCH FROM closed_at - closes_at))::float8
+        FROM polls WHERE polls.chain_id = per_poll.chain_id AND closed_at IS NOT NULL) AS avg_close_delay_seconds
+FROM per_poll
+GROUP BY per_poll.chain_id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_totals ON platform_totals(chain_id);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_creators AS
+SELECT chain_id, creator AS address, COUNT(*)::int AS polls, MAX(created_at) AS last_active_at
+FROM polls
+GROUP BY chain_id, creator;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_creators ON platform_creators(chain_id, address);
+CREATE INDEX IF NOT EXISTS idx_platform_creators_polls ON platform_creators(chain_id, polls DESC);
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS platform_voters AS
+SELECT chain_id, voter AS address,
+    COUNT(*)::int AS commits,
+    COUNT(*) FILTER (WHERE revealed)::int AS reveals,
+    MAX(committed_at) AS last_active_at
+FROM votes
+GROUP BY chain_id, voter;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_platform_voters ON platform_voters(chain_id, address);
+CREATE INDEX IF NOT EXISTS idx_platform_voters_commits ON platform_voters(chain_id, commits DESC);
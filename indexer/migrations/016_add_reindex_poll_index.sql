@@ -0,0 +1,5 @@
+-- The chain fallback queues one poll reindex per creation block, checked
+-- against every earlier reindex of the poll rather than only pending ones
+CREATE INDEX IF NOT EXISTS idx_indexer_commands_reindex_poll
+    ON indexer_commands(chain_id, poll_address, from_block)
+    WHERE kind = 'reindex_poll';
@@ -0,0 +1,227 @@
+// Package instrumentation defines the Prometheus metrics exported by the
+// indexer, API and oracle so that metric names and labels stay consistent
+// across services.
+package instrumentation
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes every metric name
+const Namespace = "blockchain_qa"
+
+// Indexer holds the metrics reported by the event indexer
+type Indexer struct {
+	// HeadLag is the number of blocks between the chain head and the last indexed block
+	HeadLag prometheus.Gauge
+	// LastBlock is the last block number the indexer has processed
+	LastBlock prometheus.Gauge
+	// BlocksProcessed counts processed blocks; rate() gives blocks per second
+	BlocksProcessed prometheus.Counter
+	// Events counts decoded events by event name
+	Events *prometheus.CounterVec
+	// HandlerErrors counts failures while handling events, by event name
+	HandlerErrors *prometheus.CounterVec
+	// RPCLatency observes Ethereum RPC call latency by method
+	RPCLatency *prometheus.HistogramVec
+	// ProvisionalMismatches counts tallied polls whose provisional count
+	// from reveals disagreed with the on-chain result
+	ProvisionalMismatches prometheus.Counter
+	// WebhookDeliveries counts webhook delivery attempts by outcome:
+	// delivered, retry or failed (retries exhausted)
+	WebhookDeliveries *prometheus.CounterVec
+	// StatsRefreshDuration observes how long refreshing the platform
+	// statistics views takes
+	StatsRefreshDuration prometheus.Histogram
+}
+
+// NewIndexer creates and registers the indexer metrics
+func NewIndexer(reg prometheus.Registerer) *Indexer {
+	m := &Indexer{
+		HeadLag: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "head_lag_blocks",
+			Help:      "Blocks between the chain head and the last indexed block.",
+		}),
+		LastBlock: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "last_block",
+			Help:      "Last block number processed by the indexer.",
+		}),
+		BlocksProcessed: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "blocks_processed_total",
+			Help:      "Blocks processed by the indexer.",
+		}),
+		Events: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "events_total",
+			Help:      "Events indexed, by event name.",
+		}, []string{"event"}),
+		HandlerErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "handler_errors_total",
+			Help:      "Errors while handling events, by event name.",
+		}, []string{"event"}),
+		RPCLatency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "rpc_duration_seconds",
+			Help:      "Ethereum RPC call latency, by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		ProvisionalMismatches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "provisional_mismatches_total",
+			Help:      "Tallied polls whose provisional count disagreed with the final result.",
+		}),
+		WebhookDeliveries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "webhook_deliveries_total",
+			Help:      "Webhook delivery attempts, by outcome.",
+		}, []string{"outcome"}),
+		StatsRefreshDuration: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "indexer",
+			Name:      "stats_refresh_duration_seconds",
+			Help:      "Time taken to refresh the platform statistics views.",
+			Buckets:   prometheus.DefBuckets,
+		}),
+	}
+
+	reg.MustRegister(m.HeadLag, m.LastBlock, m.BlocksProcessed, m.Events, m.HandlerErrors, m.RPCLatency, m.ProvisionalMismatches, m.WebhookDeliveries, m.StatsRefreshDuration)
+	return m
+}
+
+// ObserveRPC records the latency of an RPC call started at start
+func (m *Indexer) ObserveRPC(method string, start time.Time) {
+	m.RPCLatency.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+// API holds the metrics reported by the REST API
+type API struct {
+	// Requests counts HTTP requests by route template, method and status code
+	Requests *prometheus.CounterVec
+	// RequestDuration observes HTTP request latency by route template and method
+	RequestDuration *prometheus.HistogramVec
+	// Cache counts cache lookups by cache name and result (hit or miss)
+	Cache *prometheus.CounterVec
+}
+
+// NewAPI creates and registers the API metrics
+func NewAPI(reg prometheus.Registerer) *API {
+	m := &API{
+		Requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "api",
+			Name:      "requests_total",
+			Help:      "HTTP requests, by route, method and status code.",
+		}, []string{"route", "method", "status"}),
+		RequestDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: Namespace,
+			Subsystem: "api",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency, by route and method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"route", "method"}),
+		Cache: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "api",
+			Name:      "cache_requests_total",
+			Help:      "Cache lookups, by cache and result.",
+		}, []string{"cache", "result"}),
+	}
+
+	reg.MustRegister(m.Requests, m.RequestDuration, m.Cache)
+	return m
+}
+
+// CacheHit records a cache lookup result
+func (m *API) CacheHit(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.Cache.WithLabelValues(cache, result).Inc()
+}
+
+// Oracle holds the metrics reported by the mock oracle
+type Oracle struct {
+	// PendingCloses is the number of registered polls not yet closed
+	PendingCloses prometheus.Gauge
+	// ClosesSent counts close transactions sent, by response mode
+	ClosesSent *prometheus.CounterVec
+	// CloseFailures counts close attempts that failed, by response mode
+	CloseFailures *prometheus.CounterVec
+}
+
+// NewOracle creates and registers the oracle metrics
+func NewOracle(reg prometheus.Registerer) *Oracle {
+	m := &Oracle{
+		PendingCloses: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: Namespace,
+			Subsystem: "oracle",
+			Name:      "pending_close_requests",
+			Help:      "Registered polls waiting to be closed.",
+		}),
+		ClosesSent: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "oracle",
+			Name:      "closes_sent_total",
+			Help:      "Poll close transactions sent, by response mode.",
+		}, []string{"mode"}),
+		CloseFailures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: "oracle",
+			Name:      "close_failures_total",
+			Help:      "Failed poll close attempts, by response mode.",
+		}, []string{"mode"}),
+	}
+
+	reg.MustRegister(m.PendingCloses, m.ClosesSent, m.CloseFailures)
+	return m
+}
+
+// Handler returns the HTTP handler exposing the default registry
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve exposes /metrics on addr until ctx is cancelled.
+// Used by services that do not otherwise run an HTTP server.
+func Serve(ctx context.Context, addr string) {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	log.Printf("Serving metrics on %s/metrics\n", addr)
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Printf("Metrics server error: %v\n", err)
+	}
+}
@@ -9,10 +9,12 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
 	"github.com/Cosmos-Harry/blockchain-qa/oracle/internal/feeds"
 	"github.com/Cosmos-Harry/blockchain-qa/oracle/internal/publisher"
 	"github.com/Cosmos-Harry/blockchain-qa/oracle/internal/types"
 	"github.com/joho/godotenv"
+	"github.com/prometheus/client_golang/prometheus"
 )
 
 func main() {
@@ -35,8 +37,16 @@ func main() {
 	mode := getOracleModeFromEnv()
 	log.Printf("Starting oracle in %s mode\n", mode.String())
 
+	// Expose Prometheus metrics
+	metricsAddr := os.Getenv("METRICS_ADDR")
+	if metricsAddr == "" {
+		metricsAddr = ":9091"
+	}
+	oracleMetrics := instrumentation.NewOracle(prometheus.DefaultRegisterer)
+	go instrumentation.Serve(ctx, metricsAddr)
+
 	// Create time feed
-	timeFeed := feeds.NewTimeFeed(pub.GetClient(), pub.GetAuth(), mode)
+	timeFeed := feeds.NewTimeFeed(pub.GetClient(), pub.GetAuth(), mode, oracleMetrics)
 
 	// Register some test polls for demonstration
 	// In production, this would query the indexer API or listen to PollCreated events
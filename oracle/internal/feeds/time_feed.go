@@ -8,6 +8,7 @@ import (
 	"math/rand"
 	"time"
 
+	"github.com/Cosmos-Harry/blockchain-qa/instrumentation"
 	"github.com/Cosmos-Harry/blockchain-qa/oracle/internal/types"
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
@@ -20,15 +21,17 @@ type TimeFeed struct {
 	auth         *bind.TransactOpts
 	mode         types.ResponseMode
 	pollRequests map[string]*types.PollCloseRequest
+	metrics      *instrumentation.Oracle
 }
 
 // NewTimeFeed creates a new time-based oracle feed
-func NewTimeFeed(client *ethclient.Client, auth *bind.TransactOpts, mode types.ResponseMode) *TimeFeed {
+func NewTimeFeed(client *ethclient.Client, auth *bind.TransactOpts, mode types.ResponseMode, metrics *instrumentation.Oracle) *TimeFeed {
 	return &TimeFeed{
 		client:       client,
 		auth:         auth,
 		mode:         mode,
 		pollRequests: make(map[string]*types.PollCloseRequest),
+		metrics:      metrics,
 	}
 }
 
@@ -45,6 +48,7 @@ func (f *TimeFeed) RegisterPollClose(pollAddress string, deadline time.Time) {
 		Deadline:    deadline,
 		RequestedAt: time.Now(),
 	}
+	f.metrics.PendingCloses.Set(float64(len(f.pollRequests)))
 	log.Printf("Registered poll %s for closing at %s\n", pollAddress, deadline.Format(time.RFC3339))
 }
 
@@ -83,12 +87,15 @@ func (f *TimeFeed) checkAndClosePolls(ctx context.Context) {
 		}
 
 		if err := f.closePoll(ctx, pollAddress); err != nil {
+			f.metrics.CloseFailures.WithLabelValues(f.mode.String()).Inc()
 			log.Printf("Error closing poll %s: %v\n", pollAddress, err)
 			continue
 		}
+		f.metrics.ClosesSent.WithLabelValues(f.mode.String()).Inc()
 
 		// Remove from pending requests
 		delete(f.pollRequests, pollAddress)
+		f.metrics.PendingCloses.Set(float64(len(f.pollRequests)))
 		log.Printf("Successfully closed poll %s\n", pollAddress)
 	}
 }